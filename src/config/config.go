@@ -4,15 +4,27 @@ import "time"
 
 // Config is the root configuration structure
 type Config struct {
-	Agent       AgentConfig       `yaml:"agent"`
-	CodeAPI     CodeAPIConfig     `yaml:"codeapi"`
-	Concurrency ConcurrencyConfig `yaml:"concurrency"`
-	Cache       CacheConfig       `yaml:"cache"`
-	Detectors   DetectorsConfig   `yaml:"detectors"`
-	Exclusions  ExclusionsConfig  `yaml:"exclusions"`
-	Severity    SeverityConfig    `yaml:"severity"`
-	Output      OutputConfig      `yaml:"output"`
-	Logging     LoggingConfig     `yaml:"logging"`
+	Agent       AgentConfig               `yaml:"agent"`
+	CodeAPI     CodeAPIConfig             `yaml:"codeapi"`
+	Source      SourceConfig              `yaml:"source"`
+	Concurrency ConcurrencyConfig         `yaml:"concurrency"`
+	Cache       CacheConfig               `yaml:"cache"`
+	Detectors   DetectorsConfig           `yaml:"detectors"`
+	Profiles    []ProfileConfig           `yaml:"profiles"`
+	Languages   map[string]LanguageConfig `yaml:"languages"`
+	Exclusions  ExclusionsConfig          `yaml:"exclusions"`
+	Suppression SuppressionConfig         `yaml:"suppression"`
+	Severity    SeverityConfig            `yaml:"severity"`
+	Remediation RemediationConfig         `yaml:"remediation"`
+	Scoring     ScoringConfig             `yaml:"scoring"`
+	Churn       ChurnConfig               `yaml:"churn"`
+	Ownership   OwnershipConfig           `yaml:"ownership"`
+	Output      OutputConfig              `yaml:"output"`
+	QualityGate QualityGateConfig         `yaml:"quality_gate"`
+	History     HistoryConfig             `yaml:"history"`
+	Server      ServerConfig              `yaml:"server"`
+	Portfolio   PortfolioConfig           `yaml:"portfolio"`
+	Logging     LoggingConfig             `yaml:"logging"`
 }
 
 // AgentConfig contains agent metadata
@@ -38,6 +50,11 @@ type RetryConfig struct {
 	RetryOnStatus []int         `yaml:"retry_on_status"`
 }
 
+// SourceConfig describes where the analyzed source code can be read locally
+type SourceConfig struct {
+	LocalPath string `yaml:"local_path"` // Local checkout of the repository (empty = read via CodeAPI)
+}
+
 // ConcurrencyConfig contains concurrency settings
 type ConcurrencyConfig struct {
 	MaxParallelDetectors    int  `yaml:"max_parallel_detectors"`
@@ -109,6 +126,26 @@ type DuplicationDetectorConfig struct {
 	SkipTrivial         bool    `yaml:"skip_trivial"`
 }
 
+// ProfileConfig overrides detector thresholds for the files matching its
+// paths. Detectors uses the keys of the detectors section; settings not
+// mentioned keep the values in effect for the file's language.
+type ProfileConfig struct {
+	Name      string            `yaml:"name"`
+	Paths     []string          `yaml:"paths"` // Globs relative to the repository root, e.g. src/legacy/**
+	Detectors DetectorOverrides `yaml:"detectors"`
+}
+
+// LanguageConfig adjusts detectors for the files of one language. Languages
+// are keyed by the name CodeAPI reports, e.g. go, python or java.
+type LanguageConfig struct {
+	Detectors     DetectorOverrides `yaml:"detectors"`
+	DisabledRules []string          `yaml:"disabled_rules"` // Rule ids, e.g. coupling/primitive_obsession
+}
+
+// DetectorOverrides is a partial detectors section, e.g.
+// {"size_and_structure": {"max_function_lines": 120}}
+type DetectorOverrides map[string]any
+
 // ExclusionsConfig contains exclusion patterns
 type ExclusionsConfig struct {
 	FilePatterns     []string `yaml:"file_patterns"`
@@ -118,21 +155,123 @@ type ExclusionsConfig struct {
 	Languages        []string `yaml:"languages"`
 }
 
+// SuppressionConfig contains settings for inline suppression comments
+type SuppressionConfig struct {
+	Enabled  bool `yaml:"enabled"`
+	Lookback int  `yaml:"lookback"` // Lines above an entity scanned for annotations
+}
+
 // SeverityConfig contains severity settings
 type SeverityConfig struct {
 	MinSeverity string            `yaml:"min_severity"`
 	Overrides   map[string]string `yaml:"overrides"`
 }
 
+// RemediationConfig contains settings for remediation effort estimates
+type RemediationConfig struct {
+	Enabled        bool           `yaml:"enabled"`
+	RuleMinutes    map[string]int `yaml:"rule_minutes"`     // Base minutes per rule id, overriding the built-in defaults
+	MaxScale       float64        `yaml:"max_scale"`        // Cap on the threshold overshoot multiplier
+	MinutesPerLine float64        `yaml:"minutes_per_line"` // Estimated cost of developing one line, for the debt ratio
+}
+
+// ScoringConfig contains settings for the size-normalized debt score
+type ScoringConfig struct {
+	SeverityWeights map[string]float64 `yaml:"severity_weights"`
+	MinLines        int                `yaml:"min_lines"` // Areas smaller than this are scored as if they had this many lines
+	Grades          GradeBandsConfig   `yaml:"grades"`
+}
+
+// GradeBandsConfig holds the upper score bound of each letter grade;
+// scores above D are graded E
+type GradeBandsConfig struct {
+	A float64 `yaml:"a"`
+	B float64 `yaml:"b"`
+	C float64 `yaml:"c"`
+	D float64 `yaml:"d"`
+}
+
+// ChurnConfig contains settings for churn-weighted hotspots, computed from
+// the git history of the local checkout (source.local_path)
+type ChurnConfig struct {
+	Enabled    bool   `yaml:"enabled"`
+	Since      string `yaml:"since"`       // History window passed to git log --since, e.g. "12 months ago"
+	RecentDays int    `yaml:"recent_days"` // Window for recent line churn
+	TopN       int    `yaml:"top_n"`
+}
+
+// OwnershipConfig contains settings for CODEOWNERS attribution
+type OwnershipConfig struct {
+	Enabled        bool   `yaml:"enabled"`
+	CodeOwnersPath string `yaml:"codeowners_path"` // Empty = look in the usual places under source.local_path
+}
+
 // OutputConfig contains output settings
 type OutputConfig struct {
-	Formats              []string `yaml:"formats"`
-	OutputDir            string   `yaml:"output_dir"`
-	IncludeSuggestions   bool     `yaml:"include_suggestions"`
-	IncludeMetrics       bool     `yaml:"include_metrics"`
-	IncludeCodeSnippets  bool     `yaml:"include_code_snippets"`
-	MaxIssuesPerCategory int      `yaml:"max_issues_per_category"`
-	HotspotsTopN         int      `yaml:"hotspots_top_n"`
+	Formats              []string        `yaml:"formats"`
+	OutputDir            string          `yaml:"output_dir"`
+	IncludeSuggestions   bool            `yaml:"include_suggestions"`
+	IncludeMetrics       bool            `yaml:"include_metrics"`
+	IncludeCodeSnippets  bool            `yaml:"include_code_snippets"`
+	MaxIssuesPerCategory int             `yaml:"max_issues_per_category"`
+	HotspotsTopN         int             `yaml:"hotspots_top_n"`
+	PRComment            PRCommentConfig `yaml:"pr_comment"`
+	JUnit                JUnitConfig     `yaml:"junit"`
+}
+
+// JUnitConfig contains settings for the JUnit XML format
+type JUnitConfig struct {
+	FailureSeverity string `yaml:"failure_severity"` // Lowest severity rendered as a failing testcase
+	BelowThreshold  string `yaml:"below_threshold"`  // "skipped" or "passed" for less severe issues
+}
+
+// PRCommentConfig contains settings for the pull request comment format
+type PRCommentConfig struct {
+	TopN      int    `yaml:"top_n"`      // Issues shown per category
+	MaxLength int    `yaml:"max_length"` // Characters; GitHub rejects comments over 65536
+	LinkBase  string `yaml:"link_base"`  // Optional URL prefix for file links, e.g. https://github.com/org/repo/blob/<sha>/
+}
+
+// QualityGateConfig contains the conditions that fail an analysis run.
+// Limits in the maps only apply to keys that are present, so a value of 0
+// means "none allowed".
+type QualityGateConfig struct {
+	Enabled             bool           `yaml:"enabled"`
+	MaxIssuesBySeverity map[string]int `yaml:"max_issues_by_severity"`
+	MaxIssuesByCategory map[string]int `yaml:"max_issues_by_category"`
+	MaxDebtScore        float64        `yaml:"max_debt_score"` // 0 = no limit
+	FailOnNewCritical   bool           `yaml:"fail_on_new_critical"`
+	Baseline            string         `yaml:"baseline"` // Path to a previous JSON report
+}
+
+// HistoryConfig contains settings for the local history of analysis runs
+type HistoryConfig struct {
+	Enabled bool   `yaml:"enabled"`
+	Dir     string `yaml:"dir"` // One JSONL file of snapshots per repository
+}
+
+// ServerConfig contains settings for the HTTP API of the serve command
+type ServerConfig struct {
+	Addr            string        `yaml:"addr"`
+	AuthToken       string        `yaml:"auth_token"`        // Bearer token required on API calls (empty = no auth)
+	Workers         int           `yaml:"workers"`           // Analyses run concurrently
+	QueueSize       int           `yaml:"queue_size"`        // Jobs waiting for a worker before submissions are rejected
+	JobTimeout      time.Duration `yaml:"job_timeout"`       // Upper bound on a single analysis
+	JobRetention    time.Duration `yaml:"job_retention"`     // How long finished jobs and their reports are kept
+	MaxFinishedJobs int           `yaml:"max_finished_jobs"` // Oldest finished jobs are dropped beyond this count
+}
+
+// PortfolioConfig contains settings for batch analysis with analyze-all
+type PortfolioConfig struct {
+	Parallelism int                   `yaml:"parallelism"` // Repositories analyzed concurrently
+	Formats     []string              `yaml:"formats"`     // Portfolio report formats: markdown, json, csv
+	Repos       []PortfolioRepoConfig `yaml:"repos"`
+}
+
+// PortfolioRepoConfig is one repository of the batch
+type PortfolioRepoConfig struct {
+	Name      string `yaml:"name"`
+	LocalPath string `yaml:"local_path"` // Optional checkout for suppressions, churn and CODEOWNERS
 }
 
 // LoggingConfig contains logging settings
@@ -82,10 +82,44 @@ func DefaultConfig() *Config {
 			ClassPatterns:    []string{"^Test", "Mock$", "Stub$"},
 			FunctionPatterns: []string{"^test_"},
 		},
+		Suppression: SuppressionConfig{
+			Enabled:  true,
+			Lookback: 10,
+		},
 		Severity: SeverityConfig{
 			MinSeverity: "low",
 			Overrides:   map[string]string{},
 		},
+		Remediation: RemediationConfig{
+			Enabled:        true,
+			RuleMinutes:    map[string]int{},
+			MaxScale:       4,
+			MinutesPerLine: 30,
+		},
+		Scoring: ScoringConfig{
+			SeverityWeights: map[string]float64{
+				"low":      1,
+				"medium":   3,
+				"high":     7,
+				"critical": 15,
+			},
+			MinLines: 100,
+			Grades: GradeBandsConfig{
+				A: 5,
+				B: 10,
+				C: 20,
+				D: 40,
+			},
+		},
+		Churn: ChurnConfig{
+			Enabled:    false,
+			Since:      "12 months ago",
+			RecentDays: 90,
+			TopN:       10,
+		},
+		Ownership: OwnershipConfig{
+			Enabled: true,
+		},
 		Output: OutputConfig{
 			Formats:              []string{"json"},
 			OutputDir:            ".",
@@ -94,6 +128,35 @@ func DefaultConfig() *Config {
 			IncludeCodeSnippets:  false,
 			MaxIssuesPerCategory: 100,
 			HotspotsTopN:         10,
+			PRComment: PRCommentConfig{
+				TopN:      10,
+				MaxLength: 65000,
+			},
+			JUnit: JUnitConfig{
+				FailureSeverity: "medium",
+				BelowThreshold:  "skipped",
+			},
+		},
+		QualityGate: QualityGateConfig{
+			Enabled:             false,
+			MaxIssuesBySeverity: map[string]int{},
+			MaxIssuesByCategory: map[string]int{},
+		},
+		History: HistoryConfig{
+			Enabled: false,
+			Dir:     ".quality-bot/history",
+		},
+		Server: ServerConfig{
+			Addr:            "127.0.0.1:8080",
+			Workers:         2,
+			QueueSize:       32,
+			JobTimeout:      30 * time.Minute,
+			JobRetention:    24 * time.Hour,
+			MaxFinishedJobs: 200,
+		},
+		Portfolio: PortfolioConfig{
+			Parallelism: 4,
+			Formats:     []string{"markdown", "json"},
 		},
 		Logging: LoggingConfig{
 			Level:            "info",
@@ -1,10 +1,13 @@
 package config
 
 import (
+	"errors"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"regexp"
+	"strings"
 
 	"gopkg.in/yaml.v3"
 )
@@ -40,14 +43,33 @@ func (l *Loader) Load(configPath string) (*Config, error) {
 	// Expand environment variables in the YAML content
 	expandedData := l.expandEnvVars(string(data))
 
-	// Parse YAML
-	if err := yaml.Unmarshal([]byte(expandedData), cfg); err != nil {
-		return nil, fmt.Errorf("parsing config file: %w", err)
+	// Parse YAML strictly so misspelled keys are reported, not ignored
+	dec := yaml.NewDecoder(strings.NewReader(expandedData))
+	dec.KnownFields(true)
+	if err := dec.Decode(cfg); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("parsing config file %s: %w", filePath, err)
+	}
+
+	if err := cfg.Validate(); err != nil {
+		var verrs ValidationErrors
+		if errors.As(err, &verrs) {
+			var root yaml.Node
+			if yaml.Unmarshal([]byte(expandedData), &root) == nil {
+				verrs.locate(&root)
+			}
+		}
+		return nil, fmt.Errorf("invalid config file %s: %w", filePath, err)
 	}
 
 	return cfg, nil
 }
 
+// ResolvePath returns the config file Load reads: configPath if set,
+// otherwise the first default location that exists, or "" for none
+func (l *Loader) ResolvePath(configPath string) string {
+	return l.resolveConfigPath(configPath)
+}
+
 func (l *Loader) resolveConfigPath(configPath string) string {
 	if configPath != "" {
 		return configPath
@@ -0,0 +1,127 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// overridableSections are the sections an analysis may override. The others
+// reach the file system, the network or resources shared between analyses.
+var overridableSections = map[string]bool{
+	"detectors":    true,
+	"profiles":     true,
+	"languages":    true,
+	"exclusions":   true,
+	"severity":     true,
+	"scoring":      true,
+	"remediation":  true,
+	"quality_gate": true,
+}
+
+// overridableGateFields are the quality_gate keys an analysis may override;
+// the baseline is a path on the server
+var overridableGateFields = map[string]bool{
+	"enabled":                true,
+	"max_issues_by_severity": true,
+	"max_issues_by_category": true,
+	"max_debt_score":         true,
+	"fail_on_new_critical":   true,
+}
+
+// WithOverrides returns a copy of the configuration with the overrides merged
+// in. Overrides use the same keys as the YAML file, e.g.
+// {"detectors": {"complexity": {"cyclomatic_high": 20}}}; sections and fields
+// not mentioned keep their current values. Only analysis settings may be
+// overridden, unknown keys are rejected so typos do not silently fall back to
+// defaults, and the result must pass Validate.
+func (c *Config) WithOverrides(overrides map[string]any) (*Config, error) {
+	if err := checkOverridable(overrides); err != nil {
+		return nil, err
+	}
+
+	clone, err := c.clone()
+	if err != nil {
+		return nil, err
+	}
+	if len(overrides) == 0 {
+		return clone, nil
+	}
+
+	data, err := yaml.Marshal(overrides)
+	if err != nil {
+		return nil, fmt.Errorf("encoding config overrides: %w", err)
+	}
+
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(clone); err != nil {
+		return nil, fmt.Errorf("applying config overrides: %w", err)
+	}
+	if err := clone.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid config overrides: %w", err)
+	}
+	return clone, nil
+}
+
+// checkOverridable rejects overrides outside the allowed sections and fields
+func checkOverridable(overrides map[string]any) error {
+	for _, section := range sortedKeys(overrides) {
+		if !overridableSections[section] {
+			return fmt.Errorf("%s cannot be overridden per analysis (allowed: %s)",
+				section, strings.Join(sortedKeys(overridableSections), ", "))
+		}
+	}
+
+	gate, _ := overrides["quality_gate"].(map[string]any)
+	for _, field := range sortedKeys(gate) {
+		if !overridableGateFields[field] {
+			return fmt.Errorf("quality_gate.%s cannot be overridden per analysis (allowed: %s)",
+				field, strings.Join(sortedKeys(overridableGateFields), ", "))
+		}
+	}
+	return nil
+}
+
+// Apply returns a copy of base with the overrides merged in. Unknown keys are
+// rejected.
+func (o DetectorOverrides) Apply(base DetectorsConfig) (DetectorsConfig, error) {
+	var merged DetectorsConfig
+	data, err := yaml.Marshal(base)
+	if err != nil {
+		return merged, fmt.Errorf("copying detector settings: %w", err)
+	}
+	if err := yaml.Unmarshal(data, &merged); err != nil {
+		return merged, fmt.Errorf("copying detector settings: %w", err)
+	}
+	if len(o) == 0 {
+		return merged, nil
+	}
+
+	data, err = yaml.Marshal(map[string]any(o))
+	if err != nil {
+		return merged, fmt.Errorf("encoding detector overrides: %w", err)
+	}
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&merged); err != nil {
+		return merged, fmt.Errorf("applying detector overrides: %w", err)
+	}
+	return merged, nil
+}
+
+// clone deep-copies the configuration through its YAML representation
+func (c *Config) clone() (*Config, error) {
+	data, err := yaml.Marshal(c)
+	if err != nil {
+		return nil, fmt.Errorf("copying config: %w", err)
+	}
+
+	clone := &Config{}
+	if err := yaml.Unmarshal(data, clone); err != nil {
+		return nil, fmt.Errorf("copying config: %w", err)
+	}
+	return clone, nil
+}
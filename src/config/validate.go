@@ -0,0 +1,491 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"quality-bot/src/model"
+)
+
+// Accepted values of enumerated settings
+// ReportFormats are the report formats accepted in output.formats and --format
+var ReportFormats = []string{"json", "markdown", "md", "sarif", "pr-comment", "codeclimate", "junit", "html", "checkstyle", "sonarqube", "csv", "jsonl"}
+
+var (
+	severities = []string{
+		string(model.SeverityLow), string(model.SeverityMedium),
+		string(model.SeverityHigh), string(model.SeverityCritical),
+	}
+	categories = []string{
+		string(model.CategoryComplexity), string(model.CategorySize), string(model.CategoryCoupling),
+		string(model.CategoryDuplication), string(model.CategoryDeadCode),
+	}
+	portfolioFormats = []string{"markdown", "md", "json", "csv"}
+	logLevels        = []string{"debug", "info", "warn", "error"}
+	logFormats       = []string{"text", "json"}
+)
+
+// ValidationError is one invalid setting. Path is the setting's key path,
+// e.g. detectors.complexity.cyclomatic_high; Line is its line in the config
+// file, or that of its closest enclosing section, and 0 for defaults.
+type ValidationError struct {
+	Path    string
+	Line    int
+	Message string
+}
+
+func (e ValidationError) Error() string {
+	if e.Line > 0 {
+		return fmt.Sprintf("line %d: %s: %s", e.Line, e.Path, e.Message)
+	}
+	return fmt.Sprintf("%s: %s", e.Path, e.Message)
+}
+
+// ValidationErrors lists every invalid setting of a configuration
+type ValidationErrors []ValidationError
+
+func (e ValidationErrors) Error() string {
+	msgs := make([]string, len(e))
+	for i, err := range e {
+		msgs[i] = "  " + err.Error()
+	}
+	return fmt.Sprintf("%d invalid settings:\n%s", len(e), strings.Join(msgs, "\n"))
+}
+
+// locate fills in line numbers from the parsed config file and orders the
+// errors by line; errors in defaults come last
+func (e ValidationErrors) locate(root *yaml.Node) {
+	for i := range e {
+		e[i].Line = lineOf(root, e[i].Path)
+	}
+	sort.SliceStable(e, func(i, j int) bool {
+		if (e[i].Line == 0) != (e[j].Line == 0) {
+			return e[j].Line == 0
+		}
+		return e[i].Line < e[j].Line
+	})
+}
+
+// Validate checks for values that are out of range, inconsistent with each
+// other or not among the accepted choices. It returns ValidationErrors, or
+// nil if the configuration is valid.
+func (c *Config) Validate() error {
+	v := &validator{}
+
+	// CodeAPI
+	if u, err := url.Parse(c.CodeAPI.URL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		v.add("codeapi.url", "must be an http or https URL, got %q", c.CodeAPI.URL)
+	}
+	v.positiveDuration("codeapi.timeout", c.CodeAPI.Timeout)
+	retry := c.CodeAPI.Retry
+	v.min("codeapi.retry.max_attempts", retry.MaxAttempts, 0)
+	if retry.BackoffFactor < 1 {
+		v.add("codeapi.retry.backoff_factor", "must be at least 1, got %g", retry.BackoffFactor)
+	}
+	if retry.InitialDelay < 0 {
+		v.add("codeapi.retry.initial_delay", "must not be negative")
+	}
+	if retry.MaxDelay < retry.InitialDelay {
+		v.add("codeapi.retry.max_delay", "must not be below initial_delay (%s)", retry.InitialDelay)
+	}
+	for i, status := range retry.RetryOnStatus {
+		if status < 100 || status > 599 {
+			v.add(fmt.Sprintf("codeapi.retry.retry_on_status[%d]", i), "%d is not an HTTP status code", status)
+		}
+	}
+
+	// Concurrency and caching
+	v.min("concurrency.max_parallel_detectors", c.Concurrency.MaxParallelDetectors, 1)
+	v.min("concurrency.similarity_search_workers", c.Concurrency.SimilaritySearchWorkers, 1)
+	v.min("concurrency.metrics_batch_size", c.Concurrency.MetricsBatchSize, 1)
+	v.min("concurrency.rate_limit_requests_per_sec", c.Concurrency.RateLimitRequestsPerSec, 0)
+	if c.Cache.TTL < 0 {
+		v.add("cache.ttl", "must not be negative")
+	}
+	v.min("cache.max_size_mb", c.Cache.MaxSizeMB, 0)
+
+	// Detectors, language settings and path profiles
+	v.detectors("detectors", c.Detectors)
+	for _, lang := range sortedKeys(c.Languages) {
+		path := "languages." + lang
+		v.detectorOverrides(path+".detectors", c.Languages[lang].Detectors, c.Detectors)
+		for i, rule := range c.Languages[lang].DisabledRules {
+			if _, ok := model.LookupRule(rule); !ok {
+				v.add(fmt.Sprintf("%s.disabled_rules[%d]", path, i), "unknown rule id %q (expected category/subcategory, e.g. coupling/primitive_obsession)", rule)
+			}
+		}
+	}
+	profiles := make(map[string]bool)
+	for i, profile := range c.Profiles {
+		path := fmt.Sprintf("profiles[%d]", i)
+		switch {
+		case profile.Name == "":
+			v.add(path+".name", "must not be empty")
+		case profiles[profile.Name]:
+			v.add(path+".name", "profile %q is defined twice", profile.Name)
+		}
+		profiles[profile.Name] = true
+		if len(profile.Paths) == 0 {
+			v.add(path+".paths", "must list at least one glob")
+		}
+		v.globs(path+".paths", profile.Paths)
+		v.detectorOverrides(path+".detectors", profile.Detectors, c.Detectors)
+		v.profileOverLanguages(path+".detectors", profile.Detectors, c)
+	}
+
+	// Exclusions
+	v.globs("exclusions.file_patterns", c.Exclusions.FilePatterns)
+	v.regexps("exclusions.class_patterns", c.Exclusions.ClassPatterns)
+	v.regexps("exclusions.function_patterns", c.Exclusions.FunctionPatterns)
+	v.min("suppression.lookback", c.Suppression.Lookback, 0)
+
+	// Severity, effort and scoring
+	v.oneOf("severity.min_severity", c.Severity.MinSeverity, severities)
+	for _, rule := range sortedKeys(c.Severity.Overrides) {
+		v.oneOf("severity.overrides."+rule, c.Severity.Overrides[rule], severities)
+	}
+	for _, rule := range sortedKeys(c.Remediation.RuleMinutes) {
+		if _, ok := model.LookupRule(rule); !ok {
+			v.add("remediation.rule_minutes."+rule, "unknown rule id (expected category/subcategory, e.g. complexity/deep_nesting)")
+		}
+		v.min("remediation.rule_minutes."+rule, c.Remediation.RuleMinutes[rule], 0)
+	}
+	if c.Remediation.MaxScale < 1 {
+		v.add("remediation.max_scale", "must be at least 1, got %g", c.Remediation.MaxScale)
+	}
+	if c.Remediation.MinutesPerLine < 0 {
+		v.add("remediation.minutes_per_line", "must not be negative, got %g", c.Remediation.MinutesPerLine)
+	}
+	for _, sev := range sortedKeys(c.Scoring.SeverityWeights) {
+		v.key("scoring.severity_weights", sev, severities)
+		if c.Scoring.SeverityWeights[sev] < 0 {
+			v.add("scoring.severity_weights."+sev, "must not be negative, got %g", c.Scoring.SeverityWeights[sev])
+		}
+	}
+	v.min("scoring.min_lines", c.Scoring.MinLines, 0)
+	grades := c.Scoring.Grades
+	bands := []struct {
+		key   string
+		bound float64
+	}{{"a", grades.A}, {"b", grades.B}, {"c", grades.C}, {"d", grades.D}}
+	for i, band := range bands {
+		switch {
+		case band.bound < 0:
+			v.add("scoring.grades."+band.key, "must not be negative, got %g", band.bound)
+		case i > 0 && band.bound < bands[i-1].bound:
+			v.add("scoring.grades."+band.key, "must not be below grade %s (%g), got %g",
+				strings.ToUpper(bands[i-1].key), bands[i-1].bound, band.bound)
+		}
+	}
+
+	// Churn and output
+	v.min("churn.recent_days", c.Churn.RecentDays, 0)
+	v.min("churn.top_n", c.Churn.TopN, 0)
+	for i, format := range c.Output.Formats {
+		v.oneOf(fmt.Sprintf("output.formats[%d]", i), format, ReportFormats)
+	}
+	v.min("output.max_issues_per_category", c.Output.MaxIssuesPerCategory, 0)
+	v.min("output.hotspots_top_n", c.Output.HotspotsTopN, 0)
+	v.min("output.pr_comment.top_n", c.Output.PRComment.TopN, 0)
+	if c.Output.PRComment.MaxLength < 1 || c.Output.PRComment.MaxLength > 65536 {
+		v.add("output.pr_comment.max_length", "must be between 1 and 65536, got %d", c.Output.PRComment.MaxLength)
+	}
+	v.oneOf("output.junit.failure_severity", c.Output.JUnit.FailureSeverity, severities)
+	v.oneOf("output.junit.below_threshold", c.Output.JUnit.BelowThreshold, []string{"skipped", "passed"})
+
+	// Quality gate
+	for _, sev := range sortedKeys(c.QualityGate.MaxIssuesBySeverity) {
+		v.key("quality_gate.max_issues_by_severity", sev, severities)
+		v.min("quality_gate.max_issues_by_severity."+sev, c.QualityGate.MaxIssuesBySeverity[sev], 0)
+	}
+	for _, cat := range sortedKeys(c.QualityGate.MaxIssuesByCategory) {
+		v.key("quality_gate.max_issues_by_category", cat, categories)
+		v.min("quality_gate.max_issues_by_category."+cat, c.QualityGate.MaxIssuesByCategory[cat], 0)
+	}
+	if c.QualityGate.MaxDebtScore < 0 {
+		v.add("quality_gate.max_debt_score", "must not be negative, got %g", c.QualityGate.MaxDebtScore)
+	}
+
+	// Server and portfolio
+	v.min("server.workers", c.Server.Workers, 1)
+	v.min("server.queue_size", c.Server.QueueSize, 0)
+	v.positiveDuration("server.job_timeout", c.Server.JobTimeout)
+	if c.Server.JobRetention < 0 {
+		v.add("server.job_retention", "must not be negative")
+	}
+	v.min("server.max_finished_jobs", c.Server.MaxFinishedJobs, 0)
+	v.min("portfolio.parallelism", c.Portfolio.Parallelism, 1)
+	for i, format := range c.Portfolio.Formats {
+		v.oneOf(fmt.Sprintf("portfolio.formats[%d]", i), format, portfolioFormats)
+	}
+	seen := make(map[string]bool)
+	for i, repo := range c.Portfolio.Repos {
+		path := fmt.Sprintf("portfolio.repos[%d].name", i)
+		switch {
+		case repo.Name == "":
+			v.add(path, "must not be empty")
+		case seen[repo.Name]:
+			v.add(path, "repository %q is listed twice", repo.Name)
+		}
+		seen[repo.Name] = true
+	}
+
+	// Logging
+	v.oneOf("logging.level", c.Logging.Level, logLevels)
+	v.oneOf("logging.format", c.Logging.Format, logFormats)
+
+	if len(v.errs) == 0 {
+		return nil
+	}
+	return v.errs
+}
+
+// validator accumulates validation errors
+type validator struct {
+	errs ValidationErrors
+}
+
+func (v *validator) add(path, format string, args ...any) {
+	v.errs = append(v.errs, ValidationError{Path: path, Message: fmt.Sprintf(format, args...)})
+}
+
+func (v *validator) min(path string, value, minimum int) {
+	if value < minimum {
+		if minimum == 0 {
+			v.add(path, "must not be negative, got %d", value)
+			return
+		}
+		v.add(path, "must be at least %d, got %d", minimum, value)
+	}
+}
+
+func (v *validator) positiveDuration(path string, d time.Duration) {
+	if d <= 0 {
+		v.add(path, "must be a positive duration such as 30s or 5m")
+	}
+}
+
+func (v *validator) oneOf(path, value string, allowed []string) {
+	for _, a := range allowed {
+		if value == a {
+			return
+		}
+	}
+	v.add(path, "%q is not one of %s", value, strings.Join(allowed, ", "))
+}
+
+// key checks a map key; the error points at the key itself
+func (v *validator) key(path, key string, allowed []string) {
+	for _, a := range allowed {
+		if key == a {
+			return
+		}
+	}
+	v.add(path+"."+key, "unknown key %q (expected one of %s)", key, strings.Join(allowed, ", "))
+}
+
+func (v *validator) regexps(path string, patterns []string) {
+	for i, pattern := range patterns {
+		if _, err := regexp.Compile(pattern); err != nil {
+			v.add(fmt.Sprintf("%s[%d]", path, i), "invalid regular expression %q: %v", pattern, err)
+		}
+	}
+}
+
+func (v *validator) globs(path string, patterns []string) {
+	for i, pattern := range patterns {
+		if _, err := filepath.Match(pattern, ""); err != nil {
+			v.add(fmt.Sprintf("%s[%d]", path, i), "invalid glob %q: %v", pattern, err)
+		}
+	}
+}
+
+// detectors checks the thresholds of a detectors section found at path
+func (v *validator) detectors(path string, d DetectorsConfig) {
+	cx := d.Complexity
+	v.min(path+".complexity.cyclomatic_moderate", cx.CyclomaticModerate, 1)
+	if cx.CyclomaticHigh < cx.CyclomaticModerate {
+		v.add(path+".complexity.cyclomatic_high", "must not be below cyclomatic_moderate (%d), got %d", cx.CyclomaticModerate, cx.CyclomaticHigh)
+	}
+	if cx.CyclomaticCritical < cx.CyclomaticHigh {
+		v.add(path+".complexity.cyclomatic_critical", "must not be below cyclomatic_high (%d), got %d", cx.CyclomaticHigh, cx.CyclomaticCritical)
+	}
+	v.min(path+".complexity.max_nesting_depth", cx.MaxNestingDepth, 1)
+
+	size := d.SizeAndStructure
+	v.min(path+".size_and_structure.max_function_lines", size.MaxFunctionLines, 1)
+	v.min(path+".size_and_structure.max_parameters", size.MaxParameters, 0)
+	v.min(path+".size_and_structure.max_class_methods", size.MaxClassMethods, 1)
+	v.min(path+".size_and_structure.max_class_fields", size.MaxClassFields, 0)
+	v.min(path+".size_and_structure.max_file_lines", size.MaxFileLines, 1)
+	v.min(path+".size_and_structure.max_file_functions", size.MaxFileFunctions, 1)
+
+	cp := d.Coupling
+	v.min(path+".coupling.max_dependencies", cp.MaxDependencies, 0)
+	v.min(path+".coupling.feature_envy_threshold", cp.FeatureEnvyThreshold, 0)
+	v.min(path+".coupling.intimacy_call_threshold", cp.IntimacyCallThreshold, 0)
+	v.min(path+".coupling.primitive_field_threshold", cp.PrimitiveFieldThreshold, 0)
+
+	dup := d.Duplication
+	if dup.SimilarityThreshold <= 0 || dup.SimilarityThreshold > 1 {
+		v.add(path+".duplication.similarity_threshold", "must be in (0, 1], got %g", dup.SimilarityThreshold)
+	}
+	v.min(path+".duplication.min_lines", dup.MinLines, 0)
+	v.min(path+".duplication.max_functions_to_check", dup.MaxFunctionsToCheck, 0)
+	v.regexps(path+".dead_code.entry_point_patterns", d.DeadCode.EntryPointPatterns)
+}
+
+// runWideSettings apply to a whole run and cannot be overridden per language
+// or profile
+var runWideSettings = map[string]bool{
+	"fail_fast":                          true,
+	"dead_code":                          true,
+	"complexity.enabled":                 true,
+	"size_and_structure.enabled":         true,
+	"coupling.enabled":                   true,
+	"duplication.enabled":                true,
+	"duplication.max_functions_to_check": true,
+}
+
+// yamlLinePrefix is the line reference in YAML decoding errors, which for
+// overrides points into their re-encoded form rather than the config file
+var yamlLinePrefix = regexp.MustCompile(`^line [0-9]+: `)
+
+// detectorOverrides checks partial detector settings found at path and the
+// thresholds they result in when merged over base
+func (v *validator) detectorOverrides(path string, o DetectorOverrides, base DetectorsConfig) {
+	for _, section := range sortedKeys(o) {
+		if runWideSettings[section] {
+			v.add(path+"."+section, "applies to the whole run and cannot be overridden")
+			continue
+		}
+		for _, key := range sortedKeys(sectionSettings(o[section])) {
+			if runWideSettings[section+"."+key] {
+				v.add(path+"."+section+"."+key, "applies to the whole run and cannot be overridden")
+			}
+		}
+	}
+
+	merged, err := o.Apply(base)
+	var typeErr *yaml.TypeError
+	switch {
+	case errors.As(err, &typeErr):
+		for _, msg := range typeErr.Errors {
+			v.add(path, "%s", yamlLinePrefix.ReplaceAllString(msg, ""))
+		}
+		return
+	case err != nil:
+		v.add(path, "%v", err)
+		return
+	}
+	v.detectors(path, merged)
+}
+
+// sectionSettings returns the settings of a detectors section, which YAML
+// decodes with the type of the enclosing map
+func sectionSettings(section any) map[string]any {
+	switch settings := section.(type) {
+	case DetectorOverrides:
+		return settings
+	case map[string]any:
+		return settings
+	}
+	return nil
+}
+
+// profileOverLanguages checks the thresholds a profile results in for the
+// files of each configured language. Settings already invalid on their own
+// are not reported again.
+func (v *validator) profileOverLanguages(path string, o DetectorOverrides, c *Config) {
+	reported := make(map[string]bool)
+	for _, err := range v.errs {
+		reported[err.Path] = true
+	}
+
+	for _, lang := range sortedKeys(c.Languages) {
+		base, err := c.Languages[lang].Detectors.Apply(c.Detectors)
+		if err != nil {
+			continue
+		}
+		own := &validator{}
+		own.detectors("languages."+lang+".detectors", base)
+		merged, err := o.Apply(base)
+		if err != nil || len(own.errs) > 0 {
+			continue
+		}
+
+		combined := &validator{}
+		combined.detectors(path, merged)
+		for _, e := range combined.errs {
+			if !reported[e.Path] {
+				v.add(e.Path, "%s for %s files", e.Message, lang)
+				reported[e.Path] = true
+			}
+		}
+	}
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// lineOf returns the line of the setting at path ("a.b[2].c") in a parsed
+// YAML document, or of its closest enclosing section present in the file
+func lineOf(root *yaml.Node, path string) int {
+	node := root
+	if node != nil && node.Kind == yaml.DocumentNode && len(node.Content) > 0 {
+		node = node.Content[0]
+	}
+
+	line := 0
+	for _, segment := range strings.Split(path, ".") {
+		key, index := segment, -1
+		if open := strings.IndexByte(segment, '['); open > 0 && strings.HasSuffix(segment, "]") {
+			key = segment[:open]
+			if n, err := strconv.Atoi(segment[open+1 : len(segment)-1]); err == nil {
+				index = n
+			}
+		}
+
+		keyNode, value := mappingEntry(node, key)
+		if keyNode == nil {
+			return line
+		}
+		line, node = keyNode.Line, value
+		if index >= 0 {
+			if node.Kind != yaml.SequenceNode || index >= len(node.Content) {
+				return line
+			}
+			node = node.Content[index]
+			line = node.Line
+		}
+	}
+	return line
+}
+
+// mappingEntry returns the key and value nodes of key in a mapping node
+func mappingEntry(node *yaml.Node, key string) (*yaml.Node, *yaml.Node) {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil, nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i], node.Content[i+1]
+		}
+	}
+	return nil, nil
+}
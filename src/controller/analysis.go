@@ -2,19 +2,35 @@ package controller
 
 import (
 	"context"
+	"fmt"
+	"sort"
 	"time"
 
 	"quality-bot/src/config"
 	"quality-bot/src/model"
+	"quality-bot/src/service/baseline"
+	"quality-bot/src/service/churn"
 	"quality-bot/src/service/codeapi"
 	"quality-bot/src/service/detector"
+	"quality-bot/src/service/diffscope"
+	"quality-bot/src/service/gate"
+	"quality-bot/src/service/history"
 	"quality-bot/src/service/metrics"
+	"quality-bot/src/service/ownership"
+	"quality-bot/src/service/progress"
+	"quality-bot/src/service/remediation"
+	"quality-bot/src/service/scoring"
+	"quality-bot/src/service/source"
+	"quality-bot/src/service/suppression"
+	"quality-bot/src/service/telemetry"
+	"quality-bot/src/service/vcs"
 	"quality-bot/src/util"
 )
 
 // AnalysisController orchestrates the debt analysis process
 type AnalysisController struct {
-	cfg *config.Config
+	cfg    *config.Config
+	client *codeapi.Client // Shared client; nil = one per analysis
 }
 
 // NewAnalysisController creates a new analysis controller
@@ -22,20 +38,75 @@ func NewAnalysisController(cfg *config.Config) *AnalysisController {
 	return &AnalysisController{cfg: cfg}
 }
 
+// NewAnalysisControllerWithClient creates an analysis controller that uses
+// an existing CodeAPI client, so batch runs share its connections
+func NewAnalysisControllerWithClient(cfg *config.Config, client *codeapi.Client) *AnalysisController {
+	return &AnalysisController{cfg: cfg, client: client}
+}
+
 // AnalyzeRequest represents a request to analyze a repository
 type AnalyzeRequest struct {
-	RepoName  string
-	Detectors []string // Optional: specific detectors to run (empty = all)
+	RepoName     string
+	Detectors    []string // Optional: specific detectors to run (empty = all)
+	BaselinePath string   // Optional: previous JSON report to compare against
+	ChangedSince string   // Optional: only report issues on code changed since this git ref
+	ChangedFiles string   // Optional: only report issues in files listed in this file
+	Commit       string   // Optional: commit analyzed, recorded in history
+	Owners       []string // Optional: only report issues owned by these CODEOWNERS owners
 }
 
-// Analyze runs the full analysis pipeline
+// Analyze runs the full analysis pipeline, reporting progress to the sink on
+// ctx, and records failures in the telemetry registry
 func (c *AnalysisController) Analyze(ctx context.Context, req AnalyzeRequest) (*model.AnalysisReport, error) {
+	ctx = progress.WithRepo(ctx, req.RepoName)
+	startTime := time.Now()
+	progress.Emit(ctx, progress.Event{Type: progress.AnalysisStarted})
+
+	report, err := c.analyze(ctx, req)
+	finished := progress.Event{Type: progress.AnalysisFinished, DurationMs: time.Since(startTime).Milliseconds()}
+	if err != nil {
+		finished.Error = err.Error()
+		progress.Emit(ctx, finished)
+		telemetry.RecordAnalysisFailure(req.RepoName)
+		return nil, err
+	}
+	finished.Issues = report.Summary.TotalIssues
+	progress.Emit(ctx, finished)
+	return report, nil
+}
+
+func (c *AnalysisController) analyze(ctx context.Context, req AnalyzeRequest) (*model.AnalysisReport, error) {
 	startTime := time.Now()
 	util.Info("Starting analysis for repository: %s", req.RepoName)
 
-	// Create CodeAPI client
-	codeapiClient := codeapi.NewClient(c.cfg.CodeAPI)
-	util.Debug("CodeAPI client initialized (endpoint: %s)", c.cfg.CodeAPI.URL)
+	// Load the baseline up front so a bad path fails before the long run
+	var baseReport *model.AnalysisReport
+	if req.BaselinePath != "" {
+		var err error
+		baseReport, err = baseline.Load(req.BaselinePath)
+		if err != nil {
+			return nil, fmt.Errorf("loading baseline: %w", err)
+		}
+	}
+
+	// Resolve CODEOWNERS before the long run as well
+	codeOwners, err := c.loadCodeOwners(req)
+	if err != nil {
+		return nil, err
+	}
+
+	// Resolve the diff scope before the long run as well
+	changes, scope, err := c.resolveScope(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create CodeAPI client unless one is shared
+	codeapiClient := c.client
+	if codeapiClient == nil {
+		codeapiClient = codeapi.NewClient(c.cfg.CodeAPI)
+		util.Debug("CodeAPI client initialized (endpoint: %s)", c.cfg.CodeAPI.URL)
+	}
 
 	// Create metrics provider
 	metricsProvider := metrics.NewProvider(codeapiClient, req.RepoName, c.cfg.Cache)
@@ -44,41 +115,283 @@ func (c *AnalysisController) Analyze(ctx context.Context, req AnalyzeRequest) (*
 	// Create detector runner
 	detectorRunner := detector.NewRunner(metricsProvider, codeapiClient, c.cfg)
 
-	// Run all detectors
+	// Run the requested detectors, or all enabled ones
 	util.Info("Running detectors")
-	issues, err := detectorRunner.RunAll(ctx)
+	issues, err := detectorRunner.Run(ctx, req.Detectors)
 	if err != nil {
 		util.Error("Detector run failed: %v", err)
 		return nil, err
 	}
 
-	// Apply global filters
-	preFilterCount := len(issues)
-	issues = c.applyGlobalFilters(issues)
-	if preFilterCount != len(issues) {
-		util.Debug("Global filters reduced issues from %d to %d", preFilterCount, len(issues))
+	// Restrict to entities touched by the change set
+	if changes != nil {
+		scoped := changes.Filter(issues)
+		scope.OutOfScope = len(issues) - len(scoped)
+		util.Info("Diff scope: %d of %d issues are on changed code", len(scoped), len(issues))
+		issues = scoped
 	}
 
-	// Fetch code snippets if configured
-	if c.cfg.Output.IncludeCodeSnippets {
-		util.Debug("Fetching code snippets for %d issues", len(issues))
-		issues = c.fetchCodeSnippets(ctx, codeapiClient, req.RepoName, issues)
+	// Drop issues silenced by inline suppression comments
+	var suppressed []model.SuppressedIssue
+	if c.cfg.Suppression.Enabled {
+		progress.Emit(ctx, progress.Event{Type: progress.Stage, Stage: "reading suppressions"})
+		scanner := suppression.NewScanner(c.sourceReader(codeapiClient, req.RepoName), c.cfg.Suppression)
+		issues, suppressed = scanner.Apply(ctx, issues)
+		if len(suppressed) > 0 {
+			util.Info("Suppressed %d issues via inline comments", len(suppressed))
+		}
+	}
+
+	// Attribute issues to owning teams and keep only the requested owners
+	if codeOwners != nil {
+		issues = codeOwners.Apply(issues)
+		if len(req.Owners) > 0 {
+			issues = filterByOwner(issues, req.Owners)
+			util.Info("Owner filter %v: %d issues", req.Owners, len(issues))
+		}
+	}
+
+	// Estimate remediation effort per issue
+	var estimator *remediation.Estimator
+	if c.cfg.Remediation.Enabled {
+		estimator = remediation.NewEstimator(c.cfg.Remediation)
+		issues = estimator.Apply(issues)
+	}
+
+	// Line counts of the analyzed files normalize the debt score and ratio
+	progress.Emit(ctx, progress.Event{Type: progress.Stage, Stage: "scoring"})
+	files := c.analyzedFiles(ctx, metricsProvider, changes)
+	if len(req.Owners) > 0 {
+		files = filterFilesByOwner(files, codeOwners, req.Owners)
 	}
 
 	// Generate report
 	report := &model.AnalysisReport{
 		RepoName:    req.RepoName,
+		StartedAt:   startTime.UTC(),
 		GeneratedAt: time.Now().UTC(),
 		Issues:      issues,
-		Summary:     c.generateSummary(issues),
+		Suppressed:  suppressed,
+		Scope:       scope,
+		Summary:     c.generateSummary(issues, files),
+	}
+	report.Summary.SuppressedCount = len(suppressed)
+	if codeOwners != nil {
+		report.Summary.ByOwner = ownership.CountByOwner(issues)
+	}
+
+	if estimator != nil {
+		report.Summary.Remediation = estimator.Summarize(issues, files)
+	}
+
+	// Rank files by complexity and change frequency
+	if c.cfg.Churn.Enabled {
+		progress.Emit(ctx, progress.Event{Type: progress.Stage, Stage: "reading git history"})
+		report.Summary.ChurnHotspots = c.churnHotspots(ctx, files, issues)
+	}
+
+	// Compare against baseline report if provided
+	if baseReport != nil {
+		// Compare like with like when only changed code or some owners'
+		// issues are reported, so untouched code does not count as fixed
+		if changes != nil {
+			baseReport.Issues = changes.Filter(baseReport.Issues)
+		}
+		if len(req.Owners) > 0 {
+			baseReport.Issues = filterByOwner(codeOwners.Apply(baseReport.Issues), req.Owners)
+		}
+		report.Baseline = baseline.Compare(report, baseReport, req.BaselinePath)
+	}
+
+	// Evaluate quality gate if configured
+	if c.cfg.QualityGate.Enabled {
+		report.QualityGate = gate.NewEvaluator(c.cfg.QualityGate).Evaluate(report)
 	}
 
-	util.Info("Analysis complete: %d issues found, debt score: %.1f (took %v)",
-		len(issues), report.Summary.DebtScore, time.Since(startTime))
+	// Record the run in the history store; owner-filtered runs cover only
+	// part of the repository and would distort the trend
+	if c.cfg.History.Enabled && len(req.Owners) == 0 {
+		c.recordHistory(ctx, report, req.Commit)
+	}
+
+	telemetry.RecordReport(report, len(req.Owners) > 0)
+
+	// Everything above covers all issues; the cap per category only limits
+	// the issues listed in the report
+	listed := c.applyGlobalFilters(report.Issues)
+	if len(listed) != len(report.Issues) {
+		util.Debug("Listing %d of %d issues (max %d per category)", len(listed), len(report.Issues), c.cfg.Output.MaxIssuesPerCategory)
+	}
+	report.Issues = listed
+
+	// Fetch code snippets of the listed issues if configured
+	if c.cfg.Output.IncludeCodeSnippets {
+		util.Debug("Fetching code snippets for %d issues", len(report.Issues))
+		progress.Emit(ctx, progress.Event{Type: progress.Stage, Stage: "fetching snippets"})
+		report.Issues = c.fetchCodeSnippets(ctx, codeapiClient, req.RepoName, report.Issues)
+	}
+
+	util.Info("Analysis complete: %d issues found, debt score: %.1f (grade %s) (took %v)",
+		len(issues), report.Summary.DebtScore, report.Summary.DebtGrade, time.Since(startTime))
 
 	return report, nil
 }
 
+// resolveScope builds the change set for diff-scoped analysis, if requested.
+// Git is run in the configured local checkout, or the working directory.
+func (c *AnalysisController) resolveScope(ctx context.Context, req AnalyzeRequest) (*diffscope.ChangeSet, *model.AnalysisScope, error) {
+	var (
+		changes *diffscope.ChangeSet
+		err     error
+	)
+
+	switch {
+	case req.ChangedSince != "" && req.ChangedFiles != "":
+		return nil, nil, fmt.Errorf("changed-since and changed-files are mutually exclusive")
+	case req.ChangedSince != "":
+		dir := c.cfg.Source.LocalPath
+		if dir == "" {
+			dir = "."
+		}
+		changes, err = diffscope.FromGit(ctx, dir, req.ChangedSince)
+	case req.ChangedFiles != "":
+		changes, err = diffscope.FromFile(req.ChangedFiles)
+	default:
+		return nil, nil, nil
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("resolving diff scope: %w", err)
+	}
+
+	util.Info("Diff-scoped analysis: %d changed files", changes.FileCount())
+	return changes, &model.AnalysisScope{
+		ChangedSince: req.ChangedSince,
+		ChangedFiles: req.ChangedFiles,
+		FileCount:    changes.FileCount(),
+	}, nil
+}
+
+// recordHistory saves a snapshot of the report. Diff-scoped runs are not
+// recorded since they would distort trends. Failures only log a warning.
+func (c *AnalysisController) recordHistory(ctx context.Context, report *model.AnalysisReport, commit string) {
+	if report.Scope != nil {
+		util.Debug("Skipping history for diff-scoped analysis")
+		return
+	}
+
+	if commit == "" && c.cfg.Source.LocalPath != "" {
+		head, err := vcs.HeadCommit(ctx, c.cfg.Source.LocalPath)
+		if err != nil {
+			util.Debug("Could not determine commit of %s: %v", c.cfg.Source.LocalPath, err)
+		}
+		commit = head
+	}
+
+	store := history.NewStore(c.cfg.History.Dir)
+	if err := store.Save(history.NewSnapshot(report, commit)); err != nil {
+		util.Warn("Failed to record analysis history: %v", err)
+	}
+}
+
+// loadCodeOwners loads the configured CODEOWNERS file, or the one found in
+// the local checkout. An owner filter without a CODEOWNERS file is an error.
+func (c *AnalysisController) loadCodeOwners(req AnalyzeRequest) (*ownership.CodeOwners, error) {
+	if !c.cfg.Ownership.Enabled && len(req.Owners) == 0 {
+		return nil, nil
+	}
+
+	path := c.cfg.Ownership.CodeOwnersPath
+	if path == "" && c.cfg.Source.LocalPath != "" {
+		path = ownership.Find(c.cfg.Source.LocalPath)
+	}
+	if path == "" {
+		if len(req.Owners) > 0 {
+			return nil, fmt.Errorf("filtering by owner needs a CODEOWNERS file (--codeowners or --local-path)")
+		}
+		util.Debug("No CODEOWNERS file found; skipping ownership attribution")
+		return nil, nil
+	}
+
+	codeOwners, err := ownership.Load(path)
+	if err != nil {
+		return nil, fmt.Errorf("loading CODEOWNERS: %w", err)
+	}
+	return codeOwners, nil
+}
+
+func filterByOwner(issues []model.DebtIssue, owners []string) []model.DebtIssue {
+	var filtered []model.DebtIssue
+	for _, issue := range issues {
+		if ownership.OwnedBy(issue.Owners, owners) {
+			filtered = append(filtered, issue)
+		}
+	}
+	return filtered
+}
+
+// filterFilesByOwner keeps the files owned by the requested owners, so
+// size-relative figures are computed over the owners' code only
+func filterFilesByOwner(files []model.FileMetrics, codeOwners *ownership.CodeOwners, owners []string) []model.FileMetrics {
+	var filtered []model.FileMetrics
+	for _, f := range files {
+		if ownership.OwnedBy(codeOwners.Owners(f.Path), owners) {
+			filtered = append(filtered, f)
+		}
+	}
+	return filtered
+}
+
+// churnHotspots combines file complexity with the git history of the local
+// checkout. Failures are logged; churn is an optional report section.
+func (c *AnalysisController) churnHotspots(ctx context.Context, files []model.FileMetrics, issues []model.DebtIssue) []model.ChurnHotspot {
+	if c.cfg.Source.LocalPath == "" {
+		util.Warn("Churn hotspots need a local checkout (source.local_path or --local-path); skipping")
+		return nil
+	}
+
+	hist, err := churn.Analyze(ctx, c.cfg.Source.LocalPath, c.cfg.Churn)
+	if err != nil {
+		util.Warn("Failed to compute churn hotspots: %v", err)
+		return nil
+	}
+	return hist.Hotspots(files, issues, c.cfg.Churn.TopN)
+}
+
+// analyzedFiles returns the metrics of the files the analysis covers: files
+// not excluded by configuration, restricted to the change set if there is one.
+// Failures are logged and yield no files, since line counts only feed
+// size-relative figures.
+func (c *AnalysisController) analyzedFiles(ctx context.Context, provider *metrics.Provider, changes *diffscope.ChangeSet) []model.FileMetrics {
+	files, err := provider.GetAllFileMetrics(ctx)
+	if err != nil {
+		util.Warn("Failed to fetch file metrics for line counts: %v", err)
+		return nil
+	}
+
+	exclusions := util.NewExclusionMatcher(c.cfg.Exclusions)
+	var analyzed []model.FileMetrics
+	for _, f := range files {
+		if exclusions.Matches(f.Path, "", "") {
+			continue
+		}
+		if changes != nil && !changes.Touches(model.DebtIssue{FilePath: f.Path, EntityType: "file"}) {
+			continue
+		}
+		analyzed = append(analyzed, f)
+	}
+	return analyzed
+}
+
+// sourceReader returns a reader for the local checkout if one is configured,
+// falling back to CodeAPI snippets otherwise
+func (c *AnalysisController) sourceReader(client *codeapi.Client, repoName string) source.Reader {
+	if c.cfg.Source.LocalPath != "" {
+		util.Debug("Reading source from local checkout: %s", c.cfg.Source.LocalPath)
+		return source.NewLocalReader(c.cfg.Source.LocalPath)
+	}
+	return source.NewCodeAPIReader(client, repoName)
+}
+
 // fetchCodeSnippets fetches code snippets for each issue from CodeAPI
 func (c *AnalysisController) fetchCodeSnippets(ctx context.Context, client *codeapi.Client, repoName string, issues []model.DebtIssue) []model.DebtIssue {
 	fetched := 0
@@ -110,28 +423,41 @@ func (c *AnalysisController) fetchCodeSnippets(ctx context.Context, client *code
 	return issues
 }
 
+// applyGlobalFilters keeps at most max_issues_per_category issues of each
+// category, the most severe first, then by file and line, so the same issues
+// are listed on every run
 func (c *AnalysisController) applyGlobalFilters(issues []model.DebtIssue) []model.DebtIssue {
 	maxPerCategory := c.cfg.Output.MaxIssuesPerCategory
 	if maxPerCategory <= 0 {
 		return issues
 	}
 
-	byCategory := make(map[model.Category][]model.DebtIssue)
-	for _, issue := range issues {
-		if len(byCategory[issue.Category]) < maxPerCategory {
-			byCategory[issue.Category] = append(byCategory[issue.Category], issue)
+	sorted := make([]model.DebtIssue, len(issues))
+	copy(sorted, issues)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		a, b := sorted[i], sorted[j]
+		if pa, pb := model.SeverityPriority(a.Severity), model.SeverityPriority(b.Severity); pa != pb {
+			return pa < pb
+		}
+		if a.FilePath != b.FilePath {
+			return a.FilePath < b.FilePath
+		}
+		return a.StartLine < b.StartLine
+	})
+
+	perCategory := make(map[model.Category]int)
+	filtered := make([]model.DebtIssue, 0, len(sorted))
+	for _, issue := range sorted {
+		if perCategory[issue.Category] < maxPerCategory {
+			perCategory[issue.Category]++
+			filtered = append(filtered, issue)
 		}
-	}
-
-	var filtered []model.DebtIssue
-	for _, catIssues := range byCategory {
-		filtered = append(filtered, catIssues...)
 	}
 
 	return filtered
 }
 
-func (c *AnalysisController) generateSummary(issues []model.DebtIssue) model.ReportSummary {
+func (c *AnalysisController) generateSummary(issues []model.DebtIssue, analyzed []model.FileMetrics) model.ReportSummary {
 	byCategory := make(map[model.Category]int)
 	bySeverity := make(map[model.Severity]int)
 	byFile := make(map[string]int)
@@ -173,36 +499,13 @@ func (c *AnalysisController) generateSummary(issues []model.DebtIssue) model.Rep
 		}
 	}
 
-	return model.ReportSummary{
+	summary := model.ReportSummary{
 		TotalIssues:  len(issues),
 		ByCategory:   byCategory,
 		BySeverity:   bySeverity,
 		HotspotFiles: hotspots,
-		DebtScore:    c.calculateDebtScore(issues),
-	}
-}
-
-func (c *AnalysisController) calculateDebtScore(issues []model.DebtIssue) float64 {
-	if len(issues) == 0 {
-		return 0
-	}
-
-	weights := map[model.Severity]int{
-		model.SeverityLow:      1,
-		model.SeverityMedium:   3,
-		model.SeverityHigh:     7,
-		model.SeverityCritical: 15,
-	}
-
-	var total int
-	for _, issue := range issues {
-		total += weights[issue.Severity]
-	}
-
-	score := float64(total) / 10.0
-	if score > 100 {
-		score = 100
 	}
+	scoring.NewScorer(c.cfg.Scoring).Apply(&summary, issues, analyzed)
 
-	return score
+	return summary
 }
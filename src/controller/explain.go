@@ -0,0 +1,349 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"quality-bot/src/config"
+	"quality-bot/src/model"
+	"quality-bot/src/service/codeapi"
+	"quality-bot/src/service/detector"
+	"quality-bot/src/service/metrics"
+	"quality-bot/src/util"
+)
+
+// ExplainController shows how detectors judged a single entity
+type ExplainController struct {
+	cfg *config.Config
+}
+
+// NewExplainController creates a new explain controller
+func NewExplainController(cfg *config.Config) *ExplainController {
+	return &ExplainController{cfg: cfg}
+}
+
+// ExplainRequest identifies the entity to explain. Without Function or Class
+// the file itself is explained.
+type ExplainRequest struct {
+	RepoName string
+	FilePath string
+	Function string // Optional: function or method name
+	Class    string // Optional: class name, or the class of Function
+	Line     int    // Optional: start line, to tell same-named functions apart
+}
+
+// explainSession holds the state of one explanation
+type explainSession struct {
+	provider   *metrics.Provider
+	runner     *detector.Runner
+	exclusions *util.ExclusionMatcher
+	dup        *detector.DuplicationDetector
+	settings   util.Settings
+}
+
+// Explain looks up the entity, then reports its metrics, the threshold checks
+// of every detector, the exclusion rules matching it, the issues detectors
+// raise for it and the raw CodeAPI rows behind its metrics
+func (c *ExplainController) Explain(ctx context.Context, req ExplainRequest) (*model.Explanation, error) {
+	if req.FilePath == "" {
+		return nil, fmt.Errorf("a file is required")
+	}
+	util.Debug("Explaining %s (function=%q class=%q line=%d) in %s", req.FilePath, req.Function, req.Class, req.Line, req.RepoName)
+
+	client := codeapi.NewClient(c.cfg.CodeAPI)
+	provider := metrics.NewProvider(client, req.RepoName, c.cfg.Cache)
+	provider.KeepRawRows()
+	s := &explainSession{
+		provider:   provider,
+		runner:     detector.NewRunner(provider, client, c.cfg),
+		exclusions: util.NewExclusionMatcher(c.cfg.Exclusions),
+	}
+	if dup, ok := s.runner.GetDetector("duplication").(*detector.DuplicationDetector); ok {
+		s.dup = dup
+	}
+
+	s.runner.ResolveLanguages(ctx)
+	s.settings = s.runner.Settings(req.FilePath)
+
+	e := &model.Explanation{
+		RepoName: req.RepoName,
+		FilePath: req.FilePath,
+		Language: s.settings.Language,
+		Profile:  s.settings.Profile,
+	}
+	var err error
+	switch {
+	case req.Function != "":
+		err = s.explainFunction(ctx, e, req)
+	case req.Class != "":
+		err = s.explainClass(ctx, e, req)
+	default:
+		err = s.explainFile(ctx, e)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	util.Info("Explained %s %s: %d checks, %d issues", e.EntityType, e.EntityName, len(e.Checks), len(e.Issues))
+	return e, nil
+}
+
+func (s *explainSession) explainFunction(ctx context.Context, e *model.Explanation, req ExplainRequest) error {
+	functions, err := s.provider.GetAllFunctionMetrics(ctx)
+	if err != nil {
+		return err
+	}
+
+	var inFile, matches []model.FunctionMetrics
+	for _, fn := range functions {
+		if fn.FilePath != req.FilePath {
+			continue
+		}
+		inFile = append(inFile, fn)
+		if fn.Name == req.Function &&
+			(req.Class == "" || fn.ClassName == req.Class) &&
+			(req.Line == 0 || fn.StartLine == req.Line) {
+			matches = append(matches, fn)
+		}
+	}
+
+	switch {
+	case len(inFile) == 0:
+		return fmt.Errorf("no functions found in %s; check the path is relative to the repository root", req.FilePath)
+	case len(matches) == 0:
+		return fmt.Errorf("no function %q in %s (functions: %s)", qualifiedName(req.Class, req.Function), req.FilePath, describeFunctions(inFile))
+	case len(matches) > 1:
+		return fmt.Errorf("function %q is ambiguous in %s (%s); narrow it with --class or --line",
+			req.Function, req.FilePath, describeFunctions(matches))
+	}
+
+	fn := matches[0]
+	e.EntityType = "function"
+	e.EntityName = qualifiedName(fn.ClassName, fn.Name)
+	e.Function = &fn
+	for _, d := range s.runner.Explainers() {
+		e.Checks = append(e.Checks, s.markDisabled(d, d.FunctionChecks(fn))...)
+	}
+	e.Exclusions = s.exclusions.MatchingRules(fn.FilePath, fn.ClassName, fn.Name)
+
+	issues, err := s.detect(ctx)
+	if err != nil {
+		return err
+	}
+	for _, issue := range issues {
+		if issue.EntityType == "function" && issue.FilePath == fn.FilePath &&
+			issue.EntityName == fn.Name && issue.StartLine == fn.StartLine {
+			e.Issues = append(e.Issues, issue)
+		}
+	}
+
+	// Duplicates are searched for this function only rather than repo-wide
+	if s.dup != nil && s.dup.IsEnabled() && len(e.Exclusions) == 0 {
+		dupIssues, err := s.dup.DetectFunction(ctx, fn)
+		if err != nil {
+			return fmt.Errorf("searching for duplicates: %w", err)
+		}
+		e.Issues = append(e.Issues, dupIssues...)
+	}
+
+	e.RawRows = []model.RawRows{s.rawRows("function", func(r map[string]any) bool {
+		return rowString(r, "id") == fn.ID && rowString(r, "file_path") == fn.FilePath && rowString(r, "name") == fn.Name
+	})}
+	return nil
+}
+
+func (s *explainSession) explainClass(ctx context.Context, e *model.Explanation, req ExplainRequest) error {
+	classes, err := s.provider.GetAllClassMetrics(ctx)
+	if err != nil {
+		return err
+	}
+
+	var inFile, matches []model.ClassMetrics
+	for _, cls := range classes {
+		if cls.FilePath != req.FilePath {
+			continue
+		}
+		inFile = append(inFile, cls)
+		if cls.Name == req.Class {
+			matches = append(matches, cls)
+		}
+	}
+
+	switch {
+	case len(matches) == 0 && len(inFile) == 0:
+		return fmt.Errorf("no classes found in %s; check the path is relative to the repository root", req.FilePath)
+	case len(matches) == 0:
+		names := make([]string, len(inFile))
+		for i, cls := range inFile {
+			names[i] = cls.Name
+		}
+		return fmt.Errorf("no class %q in %s (classes: %s)", req.Class, req.FilePath, strings.Join(names, ", "))
+	}
+
+	cls := matches[0]
+	pairs, err := s.provider.GetClassPairMetrics(ctx)
+	if err != nil {
+		return err
+	}
+	var own []model.ClassPairMetrics
+	for _, pair := range pairs {
+		if isClass(cls, pair.Class1Name, pair.Class1File) || isClass(cls, pair.Class2Name, pair.Class2File) {
+			own = append(own, pair)
+		}
+	}
+
+	e.EntityType = "class"
+	e.EntityName = cls.Name
+	e.Class = &cls
+	for _, d := range s.runner.Explainers() {
+		e.Checks = append(e.Checks, s.markDisabled(d, d.ClassChecks(cls, own))...)
+	}
+	e.Exclusions = s.exclusions.MatchingRules(cls.FilePath, cls.Name, "")
+
+	issues, err := s.detect(ctx)
+	if err != nil {
+		return err
+	}
+	for _, issue := range issues {
+		switch issue.EntityType {
+		case "class":
+			if issue.FilePath == cls.FilePath && issue.EntityName == cls.Name {
+				e.Issues = append(e.Issues, issue)
+			}
+		case "class_pair":
+			if issue.Metrics["class1"] == cls.Name || issue.Metrics["class2"] == cls.Name {
+				e.Issues = append(e.Issues, issue)
+			}
+		}
+	}
+
+	e.RawRows = []model.RawRows{
+		s.rawRows("class", func(r map[string]any) bool {
+			return rowString(r, "id") == cls.ID && rowString(r, "file_path") == cls.FilePath && rowString(r, "name") == cls.Name
+		}),
+		s.rawRows("class_pair", func(r map[string]any) bool {
+			return isClass(cls, rowString(r, "class1_name"), rowString(r, "class1_file")) ||
+				isClass(cls, rowString(r, "class2_name"), rowString(r, "class2_file"))
+		}),
+	}
+	return nil
+}
+
+func (s *explainSession) explainFile(ctx context.Context, e *model.Explanation) error {
+	files, err := s.provider.GetAllFileMetrics(ctx)
+	if err != nil {
+		return err
+	}
+
+	var file *model.FileMetrics
+	for i := range files {
+		if files[i].Path == e.FilePath {
+			file = &files[i]
+			break
+		}
+	}
+	if file == nil {
+		return fmt.Errorf("file %s not found in %s; check the path is relative to the repository root", e.FilePath, e.RepoName)
+	}
+
+	e.EntityType = "file"
+	e.EntityName = file.Path
+	e.File = file
+	for _, d := range s.runner.Explainers() {
+		e.Checks = append(e.Checks, s.markDisabled(d, d.FileChecks(*file))...)
+	}
+	e.Exclusions = s.exclusions.MatchingRules(file.Path, "", "")
+
+	issues, err := s.detect(ctx)
+	if err != nil {
+		return err
+	}
+	for _, issue := range issues {
+		if issue.EntityType == "file" && issue.FilePath == file.Path {
+			e.Issues = append(e.Issues, issue)
+		}
+	}
+
+	e.RawRows = []model.RawRows{s.rawRows("file", func(r map[string]any) bool {
+		return rowString(r, "path") == file.Path
+	})}
+	return nil
+}
+
+// detect runs the enabled metric-based detectors, which reuse the metrics
+// already fetched. Duplication is left out; it searches the whole repository.
+func (s *explainSession) detect(ctx context.Context) ([]model.DebtIssue, error) {
+	var names []string
+	for _, d := range s.runner.Explainers() {
+		if d.IsEnabled() && d.Name() != "duplication" {
+			names = append(names, d.Name())
+		}
+	}
+	if len(names) == 0 {
+		return nil, nil
+	}
+	return s.runner.Run(ctx, names)
+}
+
+// rawRows returns the rows of one metrics query that match an entity
+func (s *explainSession) rawRows(kind string, match func(map[string]any) bool) model.RawRows {
+	lines := strings.Split(strings.TrimSpace(metrics.Query(kind)), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimPrefix(line, "\t")
+	}
+	raw := model.RawRows{Kind: kind, Query: strings.Join(lines, "\n"), Rows: []map[string]any{}}
+	for _, r := range s.provider.RawRows(kind) {
+		if match(r) {
+			raw.Rows = append(raw.Rows, r)
+		}
+	}
+	return raw
+}
+
+// markDisabled flags the checks of a disabled detector and of rules disabled
+// for the file's language, which raise no issues
+func (s *explainSession) markDisabled(d detector.Detector, checks []model.ThresholdCheck) []model.ThresholdCheck {
+	for i := range checks {
+		checks[i].Disabled = !d.IsEnabled()
+		checks[i].RuleOff = s.settings.RuleDisabled(ruleID(checks[i].Rule))
+	}
+	return checks
+}
+
+// ruleID returns the id of the rule with the given subcategory; subcategories
+// are unique across the catalog
+func ruleID(subcategory string) string {
+	for _, rule := range model.Rules {
+		if strings.HasSuffix(rule.ID, "/"+subcategory) {
+			return rule.ID
+		}
+	}
+	return ""
+}
+
+// isClass reports whether a class reference from a class pair denotes cls.
+// Pair rows may lack the file path.
+func isClass(cls model.ClassMetrics, name, file string) bool {
+	return name == cls.Name && (file == "" || file == cls.FilePath)
+}
+
+func rowString(r map[string]any, key string) string {
+	v, _ := r[key].(string)
+	return v
+}
+
+func qualifiedName(className, name string) string {
+	if className == "" {
+		return name
+	}
+	return className + "." + name
+}
+
+func describeFunctions(functions []model.FunctionMetrics) string {
+	names := make([]string, len(functions))
+	for i, fn := range functions {
+		names[i] = fmt.Sprintf("%s at line %d", qualifiedName(fn.ClassName, fn.Name), fn.StartLine)
+	}
+	return strings.Join(names, ", ")
+}
@@ -0,0 +1,105 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"quality-bot/src/config"
+	"quality-bot/src/model"
+	"quality-bot/src/service/jobs"
+	"quality-bot/src/service/progress"
+)
+
+// ErrInvalidRequest marks job requests rejected before they are queued
+var ErrInvalidRequest = errors.New("invalid request")
+
+// JobController runs analyses asynchronously for the HTTP API
+type JobController struct {
+	cfg     *config.Config
+	manager *jobs.Manager
+}
+
+// NewJobController creates a new job controller; call Start before submitting
+func NewJobController(cfg *config.Config) *JobController {
+	return &JobController{
+		cfg:     cfg,
+		manager: jobs.NewManager(cfg.Server),
+	}
+}
+
+// JobRequest is an analysis submitted through the API
+type JobRequest struct {
+	RepoName  string         `json:"repo"`
+	Detectors []string       `json:"detectors,omitempty"` // Empty = all enabled detectors
+	Owners    []string       `json:"owners,omitempty"`
+	Commit    string         `json:"commit,omitempty"`
+	Config    map[string]any `json:"config,omitempty"` // Overrides in config file syntax
+}
+
+// Start launches the job workers
+func (c *JobController) Start() {
+	c.manager.Start()
+}
+
+// Shutdown cancels outstanding jobs and waits for the workers to exit
+func (c *JobController) Shutdown(ctx context.Context) error {
+	return c.manager.Shutdown(ctx)
+}
+
+// Submit validates a request and queues its analysis
+func (c *JobController) Submit(req JobRequest) (jobs.Job, error) {
+	if req.RepoName == "" {
+		return jobs.Job{}, fmt.Errorf("%w: repo is required", ErrInvalidRequest)
+	}
+
+	cfg, err := c.cfg.WithOverrides(req.Config)
+	if err != nil {
+		return jobs.Job{}, fmt.Errorf("%w: %v", ErrInvalidRequest, err)
+	}
+
+	analyzeReq := AnalyzeRequest{
+		RepoName:  req.RepoName,
+		Detectors: req.Detectors,
+		Owners:    req.Owners,
+		Commit:    req.Commit,
+	}
+
+	return c.manager.Submit(jobs.Spec{
+		RepoName: req.RepoName,
+		Config:   cfg,
+		Task: func(ctx context.Context, update func(jobs.Progress)) (*model.AnalysisReport, error) {
+			tracker := progress.NewTracker()
+			ctx = progress.WithSink(ctx, progress.SinkFunc(func(e progress.Event) {
+				state := tracker.Apply(e)
+				update(jobs.Progress{Stage: state.Describe(), Completed: state.DetectorsDone, Total: state.DetectorsTotal})
+			}))
+			return NewAnalysisController(cfg).Analyze(ctx, analyzeReq)
+		},
+	})
+}
+
+// Get returns the state of a job
+func (c *JobController) Get(id string) (jobs.Job, error) {
+	return c.manager.Get(id)
+}
+
+// List returns all retained jobs, newest first
+func (c *JobController) List() []jobs.Job {
+	return c.manager.List()
+}
+
+// Cancel stops a queued or running job
+func (c *JobController) Cancel(id string) (jobs.Job, error) {
+	return c.manager.Cancel(id)
+}
+
+// Report renders the report of a finished job in the given format, using
+// the configuration the job ran with
+func (c *JobController) Report(id, format string) (string, error) {
+	report, cfg, err := c.manager.Result(id)
+	if err != nil {
+		return "", err
+	}
+	return NewReportController(cfg).GenerateToString(report, format)
+}
@@ -0,0 +1,82 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	"quality-bot/src/config"
+	"quality-bot/src/service/codeapi"
+	"quality-bot/src/service/export"
+	"quality-bot/src/service/metrics"
+	"quality-bot/src/util"
+)
+
+// Metric kinds that can be exported
+const (
+	MetricKindFunctions  = "functions"
+	MetricKindClasses    = "classes"
+	MetricKindFiles      = "files"
+	MetricKindClassPairs = "class_pairs"
+)
+
+// MetricKinds lists all exportable metric kinds
+var MetricKinds = []string{MetricKindFunctions, MetricKindClasses, MetricKindFiles, MetricKindClassPairs}
+
+// MetricsController exposes raw code metrics independent of detector thresholds
+type MetricsController struct {
+	cfg      *config.Config
+	provider *metrics.Provider
+}
+
+// NewMetricsController creates a new metrics controller for a repository
+func NewMetricsController(cfg *config.Config, repoName string) *MetricsController {
+	client := codeapi.NewClient(cfg.CodeAPI)
+	return &MetricsController{
+		cfg:      cfg,
+		provider: metrics.NewProvider(client, repoName, cfg.Cache),
+	}
+}
+
+// Export returns all metrics of one kind encoded as csv or jsonl
+func (c *MetricsController) Export(ctx context.Context, kind, format string) (string, error) {
+	util.Debug("Exporting %s metrics for %s as %s", kind, c.provider.RepoName(), format)
+
+	table, err := c.table(ctx, kind)
+	if err != nil {
+		return "", err
+	}
+
+	util.Info("Exporting %d %s rows", len(table.Rows), kind)
+	return table.Encode(format)
+}
+
+func (c *MetricsController) table(ctx context.Context, kind string) (export.Table, error) {
+	switch kind {
+	case MetricKindFunctions:
+		rows, err := c.provider.GetAllFunctionMetrics(ctx)
+		if err != nil {
+			return export.Table{}, err
+		}
+		return export.FromStructs(rows), nil
+	case MetricKindClasses:
+		rows, err := c.provider.GetAllClassMetrics(ctx)
+		if err != nil {
+			return export.Table{}, err
+		}
+		return export.FromStructs(rows), nil
+	case MetricKindFiles:
+		rows, err := c.provider.GetAllFileMetrics(ctx)
+		if err != nil {
+			return export.Table{}, err
+		}
+		return export.FromStructs(rows), nil
+	case MetricKindClassPairs:
+		rows, err := c.provider.GetClassPairMetrics(ctx)
+		if err != nil {
+			return export.Table{}, err
+		}
+		return export.FromStructs(rows), nil
+	default:
+		return export.Table{}, fmt.Errorf("unknown metric kind: %s", kind)
+	}
+}
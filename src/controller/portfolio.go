@@ -0,0 +1,114 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"quality-bot/src/config"
+	"quality-bot/src/model"
+	"quality-bot/src/service/codeapi"
+	"quality-bot/src/service/portfolio"
+	"quality-bot/src/service/scoring"
+	"quality-bot/src/util"
+)
+
+// PortfolioController analyzes many repositories in one batch
+type PortfolioController struct {
+	cfg *config.Config
+}
+
+// NewPortfolioController creates a new portfolio controller
+func NewPortfolioController(cfg *config.Config) *PortfolioController {
+	return &PortfolioController{cfg: cfg}
+}
+
+// PortfolioRequest represents a batch analysis
+type PortfolioRequest struct {
+	Repos       []config.PortfolioRepoConfig
+	Parallelism int           // Repositories analyzed concurrently (0 = from config)
+	Timeout     time.Duration // Per repository (0 = none)
+	OutputDir   string        // Per-repository reports are written here in the configured formats
+}
+
+// AnalyzeAll analyzes every repository with bounded parallelism, sharing one
+// CodeAPI client, and writes each repository's reports. A failing repository
+// is recorded in the portfolio and does not stop the others.
+func (c *PortfolioController) AnalyzeAll(ctx context.Context, req PortfolioRequest) (*model.PortfolioReport, error) {
+	if len(req.Repos) == 0 {
+		return nil, fmt.Errorf("no repositories to analyze (use --repos or portfolio.repos)")
+	}
+
+	parallelism := req.Parallelism
+	if parallelism <= 0 {
+		parallelism = c.cfg.Portfolio.Parallelism
+	}
+	parallelism = max(parallelism, 1)
+
+	startTime := time.Now()
+	util.Info("Analyzing %d repositories (parallelism: %d)", len(req.Repos), parallelism)
+
+	client := codeapi.NewClient(c.cfg.CodeAPI)
+	results := make([]portfolio.Result, len(req.Repos))
+	sem := make(chan struct{}, parallelism)
+	var wg sync.WaitGroup
+
+	for i, repo := range req.Repos {
+		wg.Add(1)
+		go func(i int, repo config.PortfolioRepoConfig) {
+			defer wg.Done()
+
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			results[i] = c.analyzeRepo(ctx, client, repo, req)
+		}(i, repo)
+	}
+	wg.Wait()
+
+	report := portfolio.Build(results, scoring.NewScorer(c.cfg.Scoring))
+	util.Info("Portfolio complete: %d analyzed, %d failed (took %v)",
+		report.Totals.Analyzed, report.Totals.Failed, time.Since(startTime))
+	return report, nil
+}
+
+// analyzeRepo runs and writes the analysis of one repository. Settings that
+// point at a single checkout are replaced by the repository's own.
+func (c *PortfolioController) analyzeRepo(ctx context.Context, client *codeapi.Client, repo config.PortfolioRepoConfig, req PortfolioRequest) portfolio.Result {
+	result := portfolio.Result{RepoName: repo.Name}
+
+	cfg, err := c.cfg.WithOverrides(nil)
+	if err != nil {
+		result.Err = err
+		return result
+	}
+	cfg.Source.LocalPath = repo.LocalPath
+	cfg.Ownership.CodeOwnersPath = ""
+	if req.OutputDir != "" {
+		cfg.Output.OutputDir = req.OutputDir
+	}
+
+	if req.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, req.Timeout)
+		defer cancel()
+	}
+
+	report, err := NewAnalysisControllerWithClient(cfg, client).Analyze(ctx, AnalyzeRequest{RepoName: repo.Name})
+	if err != nil {
+		util.Error("Analysis of %s failed: %v", repo.Name, err)
+		result.Err = err
+		return result
+	}
+	result.Report = report
+
+	paths, err := NewReportController(cfg).GenerateReports(report)
+	if err != nil {
+		util.Error("Writing reports for %s failed: %v", repo.Name, err)
+		result.Err = fmt.Errorf("writing reports: %w", err)
+		return result
+	}
+	result.Reports = paths
+	return result
+}
@@ -0,0 +1,97 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"quality-bot/src/config"
+	"quality-bot/src/model"
+	"quality-bot/src/service/codeapi"
+	"quality-bot/src/util"
+)
+
+// QueryController runs ad-hoc Cypher queries against the code graph
+type QueryController struct {
+	cfg *config.Config
+}
+
+// NewQueryController creates a new query controller
+func NewQueryController(cfg *config.Config) *QueryController {
+	return &QueryController{cfg: cfg}
+}
+
+// QueryRequest is a Cypher query with its parameters. $repo_name is bound
+// to RepoName unless Params sets it.
+type QueryRequest struct {
+	RepoName string
+	Query    string
+	Params   map[string]any
+}
+
+// Query runs the query with the configured CodeAPI endpoint and retries
+func (c *QueryController) Query(ctx context.Context, req QueryRequest) (*model.QueryResult, error) {
+	if strings.TrimSpace(req.Query) == "" {
+		return nil, fmt.Errorf("query is empty")
+	}
+
+	client := codeapi.NewClient(c.cfg.CodeAPI)
+	rows, err := client.ExecuteCypherWithParams(ctx, req.RepoName, req.Query, req.Params)
+	if err != nil {
+		return nil, err
+	}
+
+	util.Info("Query returned %d rows", len(rows))
+	return &model.QueryResult{
+		RepoName: req.RepoName,
+		Columns:  queryColumns(req.Query, rows),
+		Rows:     rows,
+	}, nil
+}
+
+// queryColumns orders the columns found in rows by where they appear in the
+// last RETURN clause, since CodeAPI rows carry no column order. Columns not
+// found there follow alphabetically.
+func queryColumns(query string, rows []map[string]any) []string {
+	seen := make(map[string]bool)
+	var columns []string
+	for _, row := range rows {
+		for col := range row {
+			if !seen[col] {
+				seen[col] = true
+				columns = append(columns, col)
+			}
+		}
+	}
+
+	returnClause := query
+	if i := strings.LastIndex(strings.ToUpper(query), "RETURN"); i >= 0 {
+		returnClause = query[i:]
+	}
+	// Prefer the alias ("... AS col"), then any mention of the column
+	position := func(col string) int {
+		word := `\b` + regexp.QuoteMeta(col) + `\b`
+		for _, pattern := range []string{`(?i)\bAS\s+` + word, word} {
+			if loc := regexp.MustCompile(pattern).FindStringIndex(returnClause); loc != nil {
+				return loc[0]
+			}
+		}
+		return len(returnClause)
+	}
+
+	positions := make(map[string]int, len(columns))
+	for _, col := range columns {
+		positions[col] = position(col)
+	}
+
+	sort.Slice(columns, func(i, j int) bool {
+		pi, pj := positions[columns[i]], positions[columns[j]]
+		if pi != pj {
+			return pi < pj
+		}
+		return columns[i] < columns[j]
+	})
+	return columns
+}
@@ -23,7 +23,7 @@ func NewReportController(cfg *config.Config) *ReportController {
 // GenerateReports generates reports in all configured formats
 func (c *ReportController) GenerateReports(analysisReport *model.AnalysisReport) ([]string, error) {
 	util.Debug("Generating reports for %d formats: %v", len(c.cfg.Output.Formats), c.cfg.Output.Formats)
-	reportGenerator := report.NewGenerator(c.cfg.Output)
+	reportGenerator := report.NewGenerator(c.cfg)
 	var outputPaths []string
 
 	for _, format := range c.cfg.Output.Formats {
@@ -58,14 +58,25 @@ func (c *ReportController) GenerateReports(analysisReport *model.AnalysisReport)
 
 // GenerateToString generates a report to a string
 func (c *ReportController) GenerateToString(analysisReport *model.AnalysisReport, format string) (string, error) {
-	reportGenerator := report.NewGenerator(c.cfg.Output)
+	reportGenerator := report.NewGenerator(c.cfg)
 	return reportGenerator.Generate(analysisReport, format)
 }
 
 func (c *ReportController) getOutputPath(repoName, format string) string {
 	ext := format
-	if format == "markdown" {
+	switch format {
+	case "markdown":
 		ext = "md"
+	case "pr-comment":
+		ext = "pr-comment.md"
+	case "codeclimate":
+		ext = "codeclimate.json"
+	case "junit":
+		ext = "junit.xml"
+	case "checkstyle":
+		ext = "checkstyle.xml"
+	case "sonarqube":
+		ext = "sonar.json"
 	}
 
 	filename := repoName + "-debt-report." + ext
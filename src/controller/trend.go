@@ -0,0 +1,100 @@
+package controller
+
+import (
+	"fmt"
+	"sort"
+
+	"quality-bot/src/config"
+	"quality-bot/src/model"
+	"quality-bot/src/service/history"
+	"quality-bot/src/util"
+)
+
+// TrendController builds debt trends from the history store
+type TrendController struct {
+	cfg *config.Config
+}
+
+// NewTrendController creates a new trend controller
+func NewTrendController(cfg *config.Config) *TrendController {
+	return &TrendController{cfg: cfg}
+}
+
+// TrendRequest represents a request for a repository's debt trend
+type TrendRequest struct {
+	RepoName string
+	Limit    int // Most recent runs to include (0 = all)
+	TopFiles int // Regressing files to list
+}
+
+// Trend loads a repository's history and summarizes it over time
+func (c *TrendController) Trend(req TrendRequest) (*model.TrendReport, error) {
+	store := history.NewStore(c.cfg.History.Dir)
+	snapshots, err := store.Load(req.RepoName)
+	if err != nil {
+		return nil, err
+	}
+	if len(snapshots) == 0 {
+		return nil, fmt.Errorf("no history recorded for %s in %s", req.RepoName, c.cfg.History.Dir)
+	}
+
+	if req.Limit > 0 && len(snapshots) > req.Limit {
+		snapshots = snapshots[len(snapshots)-req.Limit:]
+	}
+	util.Debug("Building trend for %s from %d snapshots", req.RepoName, len(snapshots))
+
+	trend := &model.TrendReport{RepoName: req.RepoName}
+	for _, s := range snapshots {
+		trend.Points = append(trend.Points, model.TrendPoint{
+			Commit:      s.Commit,
+			GeneratedAt: s.GeneratedAt,
+			TotalIssues: s.Summary.TotalIssues,
+			DebtScore:   s.Summary.DebtScore,
+			DebtGrade:   s.Summary.DebtGrade,
+			ByCategory:  s.Summary.ByCategory,
+			BySeverity:  s.Summary.BySeverity,
+		})
+	}
+
+	trend.RegressingFiles = regressingFiles(snapshots[0], snapshots[len(snapshots)-1], req.TopFiles)
+	return trend, nil
+}
+
+// regressingFiles returns the files whose issue count grew the most between
+// the first and last snapshot
+func regressingFiles(first, last model.Snapshot, topN int) []model.FileTrend {
+	before := countByFile(first)
+	after := countByFile(last)
+
+	var files []model.FileTrend
+	for path, count := range after {
+		if delta := count - before[path]; delta > 0 {
+			files = append(files, model.FileTrend{
+				FilePath:   path,
+				FirstCount: before[path],
+				LastCount:  count,
+				Delta:      delta,
+			})
+		}
+	}
+
+	sort.Slice(files, func(i, j int) bool {
+		if files[i].Delta != files[j].Delta {
+			return files[i].Delta > files[j].Delta
+		}
+		return files[i].FilePath < files[j].FilePath
+	})
+
+	if topN > 0 && len(files) > topN {
+		files = files[:topN]
+	}
+	return files
+}
+
+func countByFile(s model.Snapshot) map[string]int {
+	counts := make(map[string]int)
+	for _, issue := range s.Issues {
+		counts[issue.FilePath]++
+	}
+	return counts
+}
@@ -5,20 +5,33 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/spf13/cobra"
 
+	"quality-bot/src/config"
 	"quality-bot/src/controller"
+	"quality-bot/src/model"
 	"quality-bot/src/util"
 )
 
 func (h *Handler) analyzeCmd() *cobra.Command {
 	var (
-		repoName   string
-		outputFile string
-		format     string
-		timeout    time.Duration
+		repoName     string
+		outputFile   string
+		format       string
+		timeout      time.Duration
+		baseline     string
+		localPath    string
+		changedSince string
+		changedFiles string
+		commit       string
+		historyDir   string
+		churnSince   string
+		codeowners   string
+		owners       []string
+		gateFlags    gateOptions
 	)
 
 	cmd := &cobra.Command{
@@ -30,16 +43,46 @@ func (h *Handler) analyzeCmd() *cobra.Command {
 				return fmt.Errorf("--repo is required")
 			}
 
+			if localPath != "" {
+				h.cfg.Source.LocalPath = localPath
+			}
+			if codeowners != "" {
+				h.cfg.Ownership.Enabled = true
+				h.cfg.Ownership.CodeOwnersPath = codeowners
+			}
+			if churnSince != "" {
+				h.cfg.Churn.Enabled = true
+				h.cfg.Churn.Since = churnSince
+			}
+			if historyDir != "" {
+				h.cfg.History.Enabled = true
+				h.cfg.History.Dir = historyDir
+			}
+			gateFlags.apply(cmd, &h.cfg.QualityGate)
+			if baseline == "" {
+				baseline = h.cfg.QualityGate.Baseline
+			}
+
 			util.Info("Analyzing repository: %s (timeout: %v)", repoName, timeout)
 
 			ctx, cancel := context.WithTimeout(context.Background(), timeout)
 			defer cancel()
+			ctx, closeProgress, err := h.progressContext(ctx)
+			if err != nil {
+				return err
+			}
 
 			// Run analysis
 			analysisCtrl := controller.NewAnalysisController(h.cfg)
 			report, err := analysisCtrl.Analyze(ctx, controller.AnalyzeRequest{
-				RepoName: repoName,
+				RepoName:     repoName,
+				BaselinePath: baseline,
+				ChangedSince: changedSince,
+				ChangedFiles: changedFiles,
+				Commit:       commit,
+				Owners:       owners,
 			})
+			closeProgress()
 			if err != nil {
 				util.Error("Analysis failed: %v", err)
 				return fmt.Errorf("analysis failed: %w", err)
@@ -83,7 +126,25 @@ func (h *Handler) analyzeCmd() *cobra.Command {
 			// Print summary to stderr
 			fmt.Fprintf(os.Stderr, "\nAnalysis complete:\n")
 			fmt.Fprintf(os.Stderr, "  Total issues: %d\n", report.Summary.TotalIssues)
-			fmt.Fprintf(os.Stderr, "  Debt score: %.1f/100\n", report.Summary.DebtScore)
+			if report.Summary.SuppressedCount > 0 {
+				fmt.Fprintf(os.Stderr, "  Suppressed: %d\n", report.Summary.SuppressedCount)
+			}
+			fmt.Fprintf(os.Stderr, "  Debt score: %.1f per KLOC (grade %s, %d lines)\n",
+				report.Summary.DebtScore, report.Summary.DebtGrade, report.Summary.LinesOfCode)
+			if r := report.Summary.Remediation; r != nil {
+				fmt.Fprintf(os.Stderr, "  Remediation effort: %.1fh (debt ratio %.2f%%)\n", float64(r.TotalMinutes)/60, r.DebtRatio)
+			}
+
+			if report.QualityGate != nil {
+				printGateVerdict(report.QualityGate)
+				if !report.QualityGate.Passed {
+					cmd.SilenceUsage = true
+					return &exitError{
+						code: ExitGateFailure,
+						err:  fmt.Errorf("quality gate failed: %d condition(s) not met", len(report.QualityGate.Failures())),
+					}
+				}
+			}
 
 			return nil
 		},
@@ -91,10 +152,85 @@ func (h *Handler) analyzeCmd() *cobra.Command {
 
 	cmd.Flags().StringVarP(&repoName, "repo", "r", "", "Repository name (required)")
 	cmd.Flags().StringVarP(&outputFile, "output", "o", "", "Output directory path")
-	cmd.Flags().StringVarP(&format, "format", "f", "", "Output format (json, markdown, sarif)")
+	cmd.Flags().StringVarP(&format, "format", "f", "", "Output format ("+strings.Join(config.ReportFormats, ", ")+")")
 	cmd.Flags().DurationVarP(&timeout, "timeout", "t", 5*time.Minute, "Analysis timeout")
+	cmd.Flags().StringVar(&baseline, "baseline", "", "Previous JSON report to compare against")
+	cmd.Flags().StringVar(&localPath, "local-path", "", "Local checkout of the repository (for reading source)")
+	cmd.Flags().StringVar(&changedSince, "changed-since", "", "Only report issues on code changed since this git ref")
+	cmd.Flags().StringVar(&changedFiles, "changed-files", "", "Only report issues in files listed in this file (path[:start-end] per line)")
+	cmd.MarkFlagsMutuallyExclusive("changed-since", "changed-files")
+	cmd.Flags().StringVar(&commit, "commit", "", "Commit being analyzed, recorded in history (default: HEAD of --local-path)")
+	cmd.Flags().StringVar(&historyDir, "history-dir", "", "Record this run in the history store at this directory")
+	cmd.Flags().StringVar(&codeowners, "codeowners", "", "CODEOWNERS file for ownership attribution (default: found under --local-path)")
+	cmd.Flags().StringSliceVar(&owners, "owner", nil, "Only report issues owned by these CODEOWNERS owners, e.g. @org/team-payments")
+	cmd.Flags().StringVar(&churnSince, "churn-since", "", "Rank churn hotspots from git history of --local-path since this date, e.g. \"6 months ago\"")
+	gateFlags.register(cmd)
 
 	cmd.MarkFlagRequired("repo")
 
 	return cmd
 }
+
+// gateOptions holds the quality gate CLI flags. Any gate flag that is set
+// overrides the corresponding config value and enables the gate.
+type gateOptions struct {
+	enabled           bool
+	maxBySeverity     map[string]int
+	maxByCategory     map[string]int
+	maxDebtScore      float64
+	failOnNewCritical bool
+}
+
+func (o *gateOptions) register(cmd *cobra.Command) {
+	cmd.Flags().BoolVar(&o.enabled, "gate", false, "Evaluate the quality gate from config")
+	cmd.Flags().StringToIntVar(&o.maxBySeverity, "max-issues", nil, "Max issues per severity (e.g. critical=0,high=10)")
+	cmd.Flags().StringToIntVar(&o.maxByCategory, "max-category", nil, "Max issues per category (e.g. complexity=20)")
+	cmd.Flags().Float64Var(&o.maxDebtScore, "max-debt-score", 0, "Max debt score")
+	cmd.Flags().BoolVar(&o.failOnNewCritical, "fail-on-new-critical", false, "Fail if critical issues are new compared to --baseline")
+}
+
+func (o *gateOptions) apply(cmd *cobra.Command, cfg *config.QualityGateConfig) {
+	flags := cmd.Flags()
+	if flags.Changed("gate") {
+		cfg.Enabled = o.enabled
+	}
+	if flags.Changed("max-issues") {
+		cfg.Enabled = true
+		if cfg.MaxIssuesBySeverity == nil {
+			cfg.MaxIssuesBySeverity = map[string]int{}
+		}
+		for k, v := range o.maxBySeverity {
+			cfg.MaxIssuesBySeverity[k] = v
+		}
+	}
+	if flags.Changed("max-category") {
+		cfg.Enabled = true
+		if cfg.MaxIssuesByCategory == nil {
+			cfg.MaxIssuesByCategory = map[string]int{}
+		}
+		for k, v := range o.maxByCategory {
+			cfg.MaxIssuesByCategory[k] = v
+		}
+	}
+	if flags.Changed("max-debt-score") {
+		cfg.Enabled = true
+		cfg.MaxDebtScore = o.maxDebtScore
+	}
+	if flags.Changed("fail-on-new-critical") {
+		cfg.Enabled = true
+		cfg.FailOnNewCritical = o.failOnNewCritical
+	}
+}
+
+func printGateVerdict(result *model.GateResult) {
+	failures := result.Failures()
+	if result.Passed {
+		fmt.Fprintf(os.Stderr, "  Quality gate: PASSED (%d conditions)\n", len(result.Conditions))
+		return
+	}
+
+	fmt.Fprintf(os.Stderr, "  Quality gate: FAILED (%d of %d conditions)\n", len(failures), len(result.Conditions))
+	for _, c := range failures {
+		fmt.Fprintf(os.Stderr, "    - %s: %g (max %g)\n", c.Name, c.Actual, c.Threshold)
+	}
+}
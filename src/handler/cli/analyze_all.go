@@ -0,0 +1,134 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"quality-bot/src/config"
+	"quality-bot/src/controller"
+	"quality-bot/src/model"
+	"quality-bot/src/service/report"
+)
+
+func (h *Handler) analyzeAllCmd() *cobra.Command {
+	var (
+		repoNames        []string
+		outputDir        string
+		formats          []string
+		portfolioFormats []string
+		parallelism      int
+		timeout          time.Duration
+		gateFlags        gateOptions
+	)
+
+	cmd := &cobra.Command{
+		Use:   "analyze-all",
+		Short: "Analyze many repositories and write a portfolio report",
+		Long: `Analyzes every repository in --repos (or portfolio.repos in the config) with bounded
+parallelism and a shared CodeAPI client. Each repository's reports are written to the output
+directory in the configured formats, followed by a portfolio report ranking the repositories
+by size-normalized debt. A failing repository does not stop the others.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			repos := h.cfg.Portfolio.Repos
+			if len(repoNames) > 0 {
+				repos = nil
+				for _, name := range repoNames {
+					repos = append(repos, config.PortfolioRepoConfig{Name: name})
+				}
+			}
+			if outputDir != "" {
+				h.cfg.Output.OutputDir = outputDir
+			}
+			if len(formats) > 0 {
+				h.cfg.Output.Formats = formats
+			}
+			if len(portfolioFormats) > 0 {
+				h.cfg.Portfolio.Formats = portfolioFormats
+			}
+			gateFlags.apply(cmd, &h.cfg.QualityGate)
+
+			ctx, closeProgress, err := h.progressContext(context.Background())
+			if err != nil {
+				return err
+			}
+
+			portfolioCtrl := controller.NewPortfolioController(h.cfg)
+			portfolio, err := portfolioCtrl.AnalyzeAll(ctx, controller.PortfolioRequest{
+				Repos:       repos,
+				Parallelism: parallelism,
+				Timeout:     timeout,
+				OutputDir:   h.cfg.Output.OutputDir,
+			})
+			closeProgress()
+			if err != nil {
+				return err
+			}
+
+			generator := report.NewGenerator(h.cfg)
+			for _, format := range h.cfg.Portfolio.Formats {
+				output, err := generator.GeneratePortfolio(portfolio, format)
+				if err != nil {
+					return fmt.Errorf("generating portfolio: %w", err)
+				}
+				path := filepath.Join(h.cfg.Output.OutputDir, "portfolio."+portfolioExt(format))
+				if err := os.WriteFile(path, []byte(output), 0644); err != nil {
+					return fmt.Errorf("writing portfolio: %w", err)
+				}
+				fmt.Printf("Portfolio written to %s\n", path)
+			}
+
+			return portfolioVerdict(cmd, portfolio)
+		},
+	}
+
+	cmd.Flags().StringSliceVar(&repoNames, "repos", nil, "Repositories to analyze (default: portfolio.repos from config)")
+	cmd.Flags().StringVarP(&outputDir, "output", "o", "", "Directory for per-repository and portfolio reports (default: output.output_dir)")
+	cmd.Flags().StringSliceVarP(&formats, "format", "f", nil, "Per-repository report formats (default: output.formats)")
+	cmd.Flags().StringSliceVar(&portfolioFormats, "portfolio-format", nil, "Portfolio report formats: markdown, json, csv (default: portfolio.formats)")
+	cmd.Flags().IntVarP(&parallelism, "parallel", "p", 0, "Repositories analyzed concurrently (default: portfolio.parallelism)")
+	cmd.Flags().DurationVarP(&timeout, "timeout", "t", 5*time.Minute, "Analysis timeout per repository")
+	gateFlags.register(cmd)
+
+	return cmd
+}
+
+// portfolioVerdict prints the batch outcome. Failed repositories are a tool
+// failure; failed quality gates are a gate failure.
+func portfolioVerdict(cmd *cobra.Command, portfolio *model.PortfolioReport) error {
+	t := portfolio.Totals
+	fmt.Fprintf(os.Stderr, "\nPortfolio complete:\n")
+	fmt.Fprintf(os.Stderr, "  Repositories: %d analyzed, %d failed\n", t.Analyzed, t.Failed)
+	fmt.Fprintf(os.Stderr, "  Total issues: %d\n", t.TotalIssues)
+	fmt.Fprintf(os.Stderr, "  Debt score: %.1f per KLOC (grade %s, %d lines)\n", t.DebtScore, t.DebtGrade, t.LinesOfCode)
+
+	gateFailures := 0
+	for _, e := range portfolio.Repos {
+		if e.Error != "" {
+			fmt.Fprintf(os.Stderr, "  FAILED %s: %s\n", e.RepoName, e.Error)
+		}
+		if e.GatePassed != nil && !*e.GatePassed {
+			gateFailures++
+		}
+	}
+
+	cmd.SilenceUsage = true
+	switch {
+	case t.Failed > 0:
+		return &exitError{code: ExitToolFailure, err: fmt.Errorf("%d of %d repositories failed", t.Failed, t.Analyzed+t.Failed)}
+	case gateFailures > 0:
+		return &exitError{code: ExitGateFailure, err: fmt.Errorf("quality gate failed for %d repositories", gateFailures)}
+	}
+	return nil
+}
+
+func portfolioExt(format string) string {
+	if format == "markdown" {
+		return "md"
+	}
+	return format
+}
@@ -0,0 +1,57 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"quality-bot/src/config"
+)
+
+func (h *Handler) configCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "config",
+		Short: "Check the configuration",
+		// Subcommands load the configuration themselves, so an invalid file
+		// is reported rather than failing before they run
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			return nil
+		},
+	}
+	cmd.AddCommand(h.configValidateCmd())
+	return cmd
+}
+
+func (h *Handler) configValidateCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "validate [file]",
+		Short: "Validate a configuration file",
+		Long: "Decodes the configuration strictly, rejecting unknown keys, and checks values for ranges, " +
+			"consistency (e.g. cyclomatic_moderate <= cyclomatic_high), accepted choices and valid patterns. " +
+			"Errors name the setting and its line. Without an argument the file from --config or the " +
+			"default locations is validated.",
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path := h.configPath
+			if len(args) > 0 {
+				path = args[0]
+			}
+
+			loader := config.NewLoader()
+			resolved := loader.ResolvePath(path)
+			if resolved == "" {
+				fmt.Println("No configuration file found; the built-in defaults apply")
+				return nil
+			}
+
+			if _, err := loader.Load(resolved); err != nil {
+				// Run prints the error once
+				cmd.SilenceUsage = true
+				cmd.SilenceErrors = true
+				return err
+			}
+			fmt.Printf("%s is valid\n", resolved)
+			return nil
+		},
+	}
+}
@@ -0,0 +1,68 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"quality-bot/src/controller"
+	"quality-bot/src/service/report"
+)
+
+func (h *Handler) explainCmd() *cobra.Command {
+	var (
+		req        controller.ExplainRequest
+		format     string
+		outputFile string
+		timeout    time.Duration
+	)
+
+	cmd := &cobra.Command{
+		Use:   "explain",
+		Short: "Show why detectors did or did not flag a function, class or file",
+		Long: "Prints an entity's metrics, every threshold it was compared against, the exclusion rules " +
+			"matching it, the issues raised for it and the raw CodeAPI rows behind its metrics. " +
+			"Without --function or --class the file itself is explained.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, cancel := context.WithTimeout(context.Background(), timeout)
+			defer cancel()
+
+			explanation, err := controller.NewExplainController(h.cfg).Explain(ctx, req)
+			if err != nil {
+				return fmt.Errorf("explaining %s: %w", req.FilePath, err)
+			}
+
+			output, err := report.NewGenerator(h.cfg).GenerateExplanation(explanation, format)
+			if err != nil {
+				return err
+			}
+
+			if outputFile == "" {
+				fmt.Println(output)
+				return nil
+			}
+			if err := os.WriteFile(outputFile, []byte(output), 0644); err != nil {
+				return fmt.Errorf("writing explanation: %w", err)
+			}
+			fmt.Printf("Explanation written to %s\n", outputFile)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&req.RepoName, "repo", "r", "", "Repository name (required)")
+	cmd.Flags().StringVar(&req.FilePath, "file", "", "File path relative to the repository root (required)")
+	cmd.Flags().StringVar(&req.Function, "function", "", "Function or method to explain")
+	cmd.Flags().StringVar(&req.Class, "class", "", "Class to explain, or the class of --function")
+	cmd.Flags().IntVar(&req.Line, "line", 0, "Start line of the function, to tell same-named functions apart")
+	cmd.Flags().StringVarP(&format, "format", "f", "markdown", "Output format (markdown, json)")
+	cmd.Flags().StringVarP(&outputFile, "output", "o", "", "Output file path (default: stdout)")
+	cmd.Flags().DurationVarP(&timeout, "timeout", "t", 5*time.Minute, "Query timeout")
+
+	cmd.MarkFlagRequired("repo")
+	cmd.MarkFlagRequired("file")
+
+	return cmd
+}
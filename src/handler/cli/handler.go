@@ -1,20 +1,47 @@
 package cli
 
 import (
+	"errors"
 	"fmt"
 	"os"
 
 	"github.com/spf13/cobra"
 
 	"quality-bot/src/config"
+	"quality-bot/src/handler/httpapi"
 	"quality-bot/src/util"
 )
 
+// Process exit codes. A failed quality gate is distinguished from a tool
+// failure so CI pipelines can tell "debt too high" from "analysis broken".
+const (
+	ExitOK          = 0
+	ExitToolFailure = 1
+	ExitGateFailure = 2
+)
+
+// exitError carries a specific exit code through cobra's error return
+type exitError struct {
+	code int
+	err  error
+}
+
+func (e *exitError) Error() string {
+	return e.err.Error()
+}
+
+func (e *exitError) Unwrap() error {
+	return e.err
+}
+
 // Handler handles CLI commands
 type Handler struct {
-	cfg        *config.Config
-	configPath string
-	rootCmd    *cobra.Command
+	cfg         *config.Config
+	configPath  string
+	metricsAddr string
+	eventsPath  string
+	noProgress  bool
+	rootCmd     *cobra.Command
 }
 
 // New creates a new CLI handler
@@ -30,16 +57,33 @@ func (h *Handler) setupCommands() {
 		Short: "Technical debt detection agent",
 		Long:  "Analyzes codebases to detect technical debt using CodeAPI",
 		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
-			return h.loadConfig()
+			if err := h.loadConfig(); err != nil {
+				return err
+			}
+			h.serveMetrics()
+			return nil
 		},
 	}
 
 	// Global flags
 	h.rootCmd.PersistentFlags().StringVarP(&h.configPath, "config", "c", "",
 		"Path to configuration file")
+	h.rootCmd.PersistentFlags().StringVar(&h.metricsAddr, "metrics-addr", "",
+		"Serve Prometheus metrics on this address (e.g. :9090) while the command runs")
+	h.rootCmd.PersistentFlags().StringVar(&h.eventsPath, "events", "",
+		"Write progress events as NDJSON to this file, or to a file descriptor with fd:N")
+	h.rootCmd.PersistentFlags().BoolVar(&h.noProgress, "no-progress", false,
+		"Disable the live progress display on terminals")
 
 	// Add subcommands
 	h.rootCmd.AddCommand(h.analyzeCmd())
+	h.rootCmd.AddCommand(h.analyzeAllCmd())
+	h.rootCmd.AddCommand(h.trendCmd())
+	h.rootCmd.AddCommand(h.metricsCmd())
+	h.rootCmd.AddCommand(h.explainCmd())
+	h.rootCmd.AddCommand(h.queryCmd())
+	h.rootCmd.AddCommand(h.configCmd())
+	h.rootCmd.AddCommand(h.serveCmd())
 	h.rootCmd.AddCommand(h.versionCmd())
 	h.rootCmd.AddCommand(h.detectorsCmd())
 }
@@ -60,6 +104,19 @@ func (h *Handler) loadConfig() error {
 	return nil
 }
 
+// serveMetrics starts the Prometheus endpoint in the background if
+// --metrics-addr is set
+func (h *Handler) serveMetrics() {
+	if h.metricsAddr == "" {
+		return
+	}
+	go func() {
+		if err := httpapi.ServeMetrics(h.metricsAddr); err != nil {
+			util.Error("Metrics endpoint stopped: %v", err)
+		}
+	}()
+}
+
 // Execute runs the CLI
 func (h *Handler) Execute() error {
 	return h.rootCmd.Execute()
@@ -70,6 +127,11 @@ func Run() {
 	handler := New()
 	if err := handler.Execute(); err != nil {
 		fmt.Fprintln(os.Stderr, err)
-		os.Exit(1)
+
+		var exitErr *exitError
+		if errors.As(err, &exitErr) {
+			os.Exit(exitErr.code)
+		}
+		os.Exit(ExitToolFailure)
 	}
 }
@@ -0,0 +1,86 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"quality-bot/src/controller"
+)
+
+func (h *Handler) metricsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "metrics",
+		Short: "Inspect raw code metrics",
+	}
+	cmd.AddCommand(h.metricsExportCmd())
+	return cmd
+}
+
+func (h *Handler) metricsExportCmd() *cobra.Command {
+	var (
+		repoName  string
+		kinds     []string
+		format    string
+		outputDir string
+		timeout   time.Duration
+	)
+
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export function, class, file and class-pair metrics",
+		Long: "Dumps the metrics detectors are evaluated on, whether or not any threshold was crossed, " +
+			"so thresholds can be tuned against the real distribution",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(kinds) == 0 {
+				kinds = controller.MetricKinds
+			}
+			if outputDir == "-" && len(kinds) > 1 {
+				return fmt.Errorf("writing to stdout requires a single --kind")
+			}
+			if outputDir == "" {
+				outputDir = h.cfg.Output.OutputDir
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), timeout)
+			defer cancel()
+
+			metricsCtrl := controller.NewMetricsController(h.cfg, repoName)
+			for _, kind := range kinds {
+				output, err := metricsCtrl.Export(ctx, kind, format)
+				if err != nil {
+					return fmt.Errorf("exporting %s metrics: %w", kind, err)
+				}
+
+				if outputDir == "-" {
+					fmt.Print(output)
+					continue
+				}
+
+				path := filepath.Join(outputDir, fmt.Sprintf("%s-%s-metrics.%s", repoName, kind, format))
+				if err := os.MkdirAll(outputDir, 0755); err != nil {
+					return fmt.Errorf("creating output directory: %w", err)
+				}
+				if err := os.WriteFile(path, []byte(output), 0644); err != nil {
+					return fmt.Errorf("writing %s: %w", path, err)
+				}
+				fmt.Printf("Metrics written to %s\n", path)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&repoName, "repo", "r", "", "Repository name (required)")
+	cmd.Flags().StringSliceVarP(&kinds, "kind", "k", nil, "Metric kinds: functions, classes, files, class_pairs (default: all)")
+	cmd.Flags().StringVarP(&format, "format", "f", "csv", "Output format (csv, jsonl)")
+	cmd.Flags().StringVarP(&outputDir, "output", "o", "", "Output directory, or - for stdout (default: output.output_dir)")
+	cmd.Flags().DurationVarP(&timeout, "timeout", "t", 5*time.Minute, "Query timeout")
+
+	cmd.MarkFlagRequired("repo")
+
+	return cmd
+}
@@ -0,0 +1,81 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"quality-bot/src/service/progress"
+)
+
+// progressContext attaches the progress sinks requested on the command line:
+// NDJSON events for --events and a live display when stderr is a terminal.
+// The returned function flushes them and closes those it opened.
+func (h *Handler) progressContext(ctx context.Context) (context.Context, func(), error) {
+	var closers []func()
+
+	if h.eventsPath != "" {
+		w, err := openEvents(h.eventsPath)
+		if err != nil {
+			return ctx, func() {}, err
+		}
+		ctx = progress.WithSink(ctx, progress.NewNDJSONSink(w))
+		closers = append(closers, func() { w.Close() })
+	}
+
+	if !h.noProgress && isTerminal(os.Stderr) {
+		display := progress.NewDisplay(os.Stderr)
+		ctx = progress.WithSink(ctx, display)
+		closers = append(closers, display.Close)
+	}
+
+	return ctx, func() {
+		for _, c := range closers {
+			c()
+		}
+	}, nil
+}
+
+// inheritedFile is an inherited file descriptor. Closing it is a no-op: the
+// command may still write its report to stdout or logs to stderr after the
+// events, and the descriptor belongs to the parent process.
+type inheritedFile struct {
+	*os.File
+}
+
+// Close does nothing; writes to the descriptor are unbuffered
+func (inheritedFile) Close() error {
+	return nil
+}
+
+// openEvents opens the --events target: "fd:N" for an inherited file
+// descriptor, anything else is a file path
+func openEvents(target string) (io.WriteCloser, error) {
+	if fd, ok := strings.CutPrefix(target, "fd:"); ok {
+		n, err := strconv.Atoi(fd)
+		if err != nil || n < 0 {
+			return nil, fmt.Errorf("invalid events file descriptor %q", target)
+		}
+		switch n {
+		case 1:
+			return inheritedFile{os.Stdout}, nil
+		case 2:
+			return inheritedFile{os.Stderr}, nil
+		}
+		return inheritedFile{os.NewFile(uintptr(n), "events")}, nil
+	}
+
+	f, err := os.Create(target)
+	if err != nil {
+		return nil, fmt.Errorf("opening events file: %w", err)
+	}
+	return f, nil
+}
+
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
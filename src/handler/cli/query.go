@@ -0,0 +1,148 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"quality-bot/src/controller"
+	"quality-bot/src/service/report"
+)
+
+func (h *Handler) queryCmd() *cobra.Command {
+	var (
+		repoName   string
+		queryFile  string
+		params     []string
+		format     string
+		outputFile string
+		timeout    time.Duration
+	)
+
+	cmd := &cobra.Command{
+		Use:   "query [cypher]",
+		Short: "Run an ad-hoc Cypher query against the code graph",
+		Long: "Runs a Cypher query through CodeAPI with the configured endpoint and retries. " +
+			"$repo_name is bound to --repo; other $name parameters are set with --param name=value, " +
+			"where the value is parsed as JSON (numbers, booleans, null, quoted strings, lists) " +
+			"and taken as a plain string otherwise.",
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			query, err := readQuery(args, queryFile)
+			if err != nil {
+				return err
+			}
+			bound, err := parseParams(params)
+			if err != nil {
+				return err
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), timeout)
+			defer cancel()
+
+			result, err := controller.NewQueryController(h.cfg).Query(ctx, controller.QueryRequest{
+				RepoName: repoName,
+				Query:    query,
+				Params:   bound,
+			})
+			if err != nil {
+				return fmt.Errorf("running query: %w", err)
+			}
+
+			output, err := report.NewGenerator(h.cfg).GenerateQueryResult(result, format)
+			if err != nil {
+				return err
+			}
+
+			if outputFile == "" {
+				fmt.Println(output)
+				return nil
+			}
+			if err := os.WriteFile(outputFile, []byte(output), 0644); err != nil {
+				return fmt.Errorf("writing query result: %w", err)
+			}
+			fmt.Printf("Query result written to %s\n", outputFile)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&repoName, "repo", "r", "", "Repository name (required)")
+	cmd.Flags().StringVar(&queryFile, "file", "", "Read the query from a file, or - for stdin")
+	cmd.Flags().StringArrayVarP(&params, "param", "p", nil, "Query parameter as name=value (repeatable)")
+	cmd.Flags().StringVarP(&format, "format", "f", "table", "Output format (table, json, csv)")
+	cmd.Flags().StringVarP(&outputFile, "output", "o", "", "Output file path (default: stdout)")
+	cmd.Flags().DurationVarP(&timeout, "timeout", "t", 5*time.Minute, "Query timeout")
+
+	cmd.MarkFlagRequired("repo")
+
+	return cmd
+}
+
+// readQuery takes the query from the argument or from --file
+func readQuery(args []string, queryFile string) (string, error) {
+	switch {
+	case len(args) > 0 && queryFile != "":
+		return "", fmt.Errorf("give the query as an argument or with --file, not both")
+	case len(args) > 0:
+		return args[0], nil
+	case queryFile == "-":
+		data, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			return "", fmt.Errorf("reading query from stdin: %w", err)
+		}
+		return string(data), nil
+	case queryFile != "":
+		data, err := os.ReadFile(queryFile)
+		if err != nil {
+			return "", fmt.Errorf("reading query: %w", err)
+		}
+		return string(data), nil
+	default:
+		return "", fmt.Errorf("a query argument or --file is required")
+	}
+}
+
+// parseParams parses name=value pairs. Values that are valid JSON keep their
+// type, so --param min=10 binds a number and --param 'id="10"' a string.
+// Numbers are bound exactly as written, without a float64 round trip.
+func parseParams(pairs []string) (map[string]any, error) {
+	params := make(map[string]any, len(pairs))
+	for _, pair := range pairs {
+		name, raw, ok := strings.Cut(pair, "=")
+		name = strings.TrimPrefix(strings.TrimSpace(name), "$")
+		if !ok || name == "" {
+			return nil, fmt.Errorf("invalid --param %q: expected name=value", pair)
+		}
+
+		value, err := decodeParam(raw)
+		if err != nil {
+			value = raw
+		}
+		if _, isMap := value.(map[string]any); isMap {
+			return nil, fmt.Errorf("invalid --param %q: maps are not supported", pair)
+		}
+		params[name] = value
+	}
+	return params, nil
+}
+
+// decodeParam decodes a single JSON value, keeping numbers as json.Number
+func decodeParam(raw string) (any, error) {
+	dec := json.NewDecoder(strings.NewReader(raw))
+	dec.UseNumber()
+
+	var value any
+	if err := dec.Decode(&value); err != nil {
+		return nil, err
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		return nil, fmt.Errorf("trailing data after JSON value")
+	}
+	return value, nil
+}
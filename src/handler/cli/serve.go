@@ -0,0 +1,65 @@
+package cli
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"quality-bot/src/controller"
+	"quality-bot/src/handler/httpapi"
+	"quality-bot/src/util"
+)
+
+func (h *Handler) serveCmd() *cobra.Command {
+	var (
+		addr    string
+		workers int
+	)
+
+	cmd := &cobra.Command{
+		Use:   "serve",
+		Short: "Serve a REST API for asynchronous analyses",
+		Long: `Starts an HTTP server that accepts analysis jobs, runs them on a bounded
+worker pool and serves their reports in any output format.
+
+Endpoints:
+  POST   /api/v1/jobs                        submit {"repo", "detectors", "owners", "commit", "config"}
+  GET    /api/v1/jobs                        list retained jobs
+  GET    /api/v1/jobs/{id}                   job status and progress
+  DELETE /api/v1/jobs/{id}                   cancel a queued or running job
+  GET    /api/v1/jobs/{id}/report?format=... report of a finished job (default json)
+  GET    /healthz                            liveness check`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if addr != "" {
+				h.cfg.Server.Addr = addr
+			}
+			if workers > 0 {
+				h.cfg.Server.Workers = workers
+			}
+
+			ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+
+			jobCtrl := controller.NewJobController(h.cfg)
+			jobCtrl.Start()
+
+			err := httpapi.New(h.cfg.Server, jobCtrl).ListenAndServe(ctx)
+
+			shutdownCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+			defer cancel()
+			if shutdownErr := jobCtrl.Shutdown(shutdownCtx); shutdownErr != nil {
+				util.Warn("Jobs did not stop in time: %v", shutdownErr)
+			}
+			return err
+		},
+	}
+
+	cmd.Flags().StringVar(&addr, "addr", "", "Listen address (default from config, 127.0.0.1:8080); non-loopback addresses need server.auth_token")
+	cmd.Flags().IntVar(&workers, "workers", 0, "Number of analyses run concurrently (default from config)")
+
+	return cmd
+}
@@ -0,0 +1,69 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"quality-bot/src/controller"
+	"quality-bot/src/service/report"
+)
+
+func (h *Handler) trendCmd() *cobra.Command {
+	var (
+		repoName   string
+		format     string
+		outputFile string
+		historyDir string
+		limit      int
+		topFiles   int
+	)
+
+	cmd := &cobra.Command{
+		Use:   "trend",
+		Short: "Show how a repository's technical debt evolved over time",
+		Long:  "Reads recorded analysis runs from the history store and prints debt score, issue counts and top regressing files",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if historyDir != "" {
+				h.cfg.History.Dir = historyDir
+			}
+
+			trendCtrl := controller.NewTrendController(h.cfg)
+			trend, err := trendCtrl.Trend(controller.TrendRequest{
+				RepoName: repoName,
+				Limit:    limit,
+				TopFiles: topFiles,
+			})
+			if err != nil {
+				return fmt.Errorf("building trend: %w", err)
+			}
+
+			output, err := report.NewGenerator(h.cfg).GenerateTrend(trend, format)
+			if err != nil {
+				return err
+			}
+
+			if outputFile == "" {
+				fmt.Println(output)
+				return nil
+			}
+			if err := os.WriteFile(outputFile, []byte(output), 0644); err != nil {
+				return fmt.Errorf("writing trend: %w", err)
+			}
+			fmt.Printf("Trend written to %s\n", outputFile)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&repoName, "repo", "r", "", "Repository name (required)")
+	cmd.Flags().StringVarP(&format, "format", "f", "markdown", "Output format (markdown, json, csv)")
+	cmd.Flags().StringVarP(&outputFile, "output", "o", "", "Output file path (default: stdout)")
+	cmd.Flags().StringVar(&historyDir, "history-dir", "", "History store directory (overrides config)")
+	cmd.Flags().IntVarP(&limit, "limit", "n", 0, "Number of most recent runs to include (0 = all)")
+	cmd.Flags().IntVar(&topFiles, "top-files", 10, "Number of regressing files to list")
+
+	cmd.MarkFlagRequired("repo")
+
+	return cmd
+}
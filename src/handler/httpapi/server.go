@@ -0,0 +1,234 @@
+package httpapi
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	"quality-bot/src/config"
+	"quality-bot/src/controller"
+	"quality-bot/src/service/jobs"
+	"quality-bot/src/service/telemetry"
+	"quality-bot/src/util"
+)
+
+// maxRequestBytes bounds the size of a submitted job request
+const maxRequestBytes = 1 << 20
+
+// contentTypes maps report formats to the Content-Type they are served with
+var contentTypes = map[string]string{
+	"json":        "application/json",
+	"sarif":       "application/sarif+json",
+	"codeclimate": "application/json",
+	"sonarqube":   "application/json",
+	"markdown":    "text/markdown; charset=utf-8",
+	"md":          "text/markdown; charset=utf-8",
+	"pr-comment":  "text/markdown; charset=utf-8",
+	"html":        "text/html; charset=utf-8",
+	"junit":       "application/xml",
+	"checkstyle":  "application/xml",
+	"csv":         "text/csv; charset=utf-8",
+	"jsonl":       "application/x-ndjson",
+}
+
+// Server exposes analysis jobs over a REST API
+type Server struct {
+	cfg  config.ServerConfig
+	jobs *controller.JobController
+	mux  *http.ServeMux
+}
+
+// New creates a new API server backed by the job controller
+func New(cfg config.ServerConfig, jobCtrl *controller.JobController) *Server {
+	s := &Server{cfg: cfg, jobs: jobCtrl, mux: http.NewServeMux()}
+	s.routes()
+	return s
+}
+
+func (s *Server) routes() {
+	s.mux.HandleFunc("GET /healthz", s.handleHealth)
+	s.mux.Handle("POST /api/v1/jobs", s.authenticated(s.handleSubmit))
+	s.mux.Handle("GET /api/v1/jobs", s.authenticated(s.handleList))
+	s.mux.Handle("GET /api/v1/jobs/{id}", s.authenticated(s.handleGet))
+	s.mux.Handle("DELETE /api/v1/jobs/{id}", s.authenticated(s.handleCancel))
+	s.mux.Handle("GET /api/v1/jobs/{id}/report", s.authenticated(s.handleReport))
+	s.mux.Handle("GET /metrics", s.authenticated(telemetry.Default.Handler().ServeHTTP))
+}
+
+// Handler returns the HTTP handler serving the API
+func (s *Server) Handler() http.Handler {
+	return s.mux
+}
+
+// ListenAndServe serves the API until ctx is canceled, then shuts down
+// gracefully. Without an auth token it only listens on loopback addresses.
+func (s *Server) ListenAndServe(ctx context.Context) error {
+	if s.cfg.AuthToken == "" && !isLoopback(s.cfg.Addr) {
+		return fmt.Errorf("refusing to serve the API on %s without server.auth_token; set a token or listen on a loopback address such as 127.0.0.1:8080", s.cfg.Addr)
+	}
+
+	srv := &http.Server{
+		Addr:              s.cfg.Addr,
+		Handler:           s.mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	errChan := make(chan error, 1)
+	go func() {
+		util.Info("API server listening on %s", s.cfg.Addr)
+		errChan <- srv.ListenAndServe()
+	}()
+
+	select {
+	case err := <-errChan:
+		return fmt.Errorf("serving API: %w", err)
+	case <-ctx.Done():
+	}
+
+	util.Info("Shutting down API server")
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	return srv.Shutdown(shutdownCtx)
+}
+
+// ServeMetrics serves only the /metrics endpoint on addr, for Prometheus to
+// scrape long CLI runs. It blocks until the listener fails.
+func ServeMetrics(addr string) error {
+	mux := http.NewServeMux()
+	mux.Handle("GET /metrics", telemetry.Default.Handler())
+
+	srv := &http.Server{Addr: addr, Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+	util.Info("Serving metrics on %s/metrics", addr)
+	return srv.ListenAndServe()
+}
+
+// isLoopback reports whether addr only accepts local connections. An empty
+// host (":8080") listens on all interfaces.
+func isLoopback(addr string) bool {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil || host == "" {
+		return false
+	}
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+// authenticated requires the configured bearer token, if any
+func (s *Server) authenticated(next http.HandlerFunc) http.Handler {
+	if s.cfg.AuthToken == "" {
+		return next
+	}
+	want := []byte("Bearer " + s.cfg.AuthToken)
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got := []byte(r.Header.Get("Authorization"))
+		if subtle.ConstantTimeCompare(got, want) != 1 {
+			writeError(w, http.StatusUnauthorized, "missing or invalid bearer token")
+			return
+		}
+		next(w, r)
+	})
+}
+
+func (s *Server) handleHealth(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]string{"status": "ok"})
+}
+
+func (s *Server) handleSubmit(w http.ResponseWriter, r *http.Request) {
+	var req controller.JobRequest
+	dec := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxRequestBytes))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("decoding request: %v", err))
+		return
+	}
+
+	job, err := s.jobs.Submit(req)
+	if err != nil {
+		writeJobError(w, err)
+		return
+	}
+
+	w.Header().Set("Location", "/api/v1/jobs/"+job.ID)
+	writeJSON(w, http.StatusAccepted, job)
+}
+
+func (s *Server) handleList(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string][]jobs.Job{"jobs": s.jobs.List()})
+}
+
+func (s *Server) handleGet(w http.ResponseWriter, r *http.Request) {
+	job, err := s.jobs.Get(r.PathValue("id"))
+	if err != nil {
+		writeJobError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, job)
+}
+
+func (s *Server) handleCancel(w http.ResponseWriter, r *http.Request) {
+	job, err := s.jobs.Cancel(r.PathValue("id"))
+	if err != nil {
+		writeJobError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusAccepted, job)
+}
+
+func (s *Server) handleReport(w http.ResponseWriter, r *http.Request) {
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = "json"
+	}
+	contentType, ok := contentTypes[format]
+	if !ok {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("unsupported format: %s", format))
+		return
+	}
+
+	output, err := s.jobs.Report(r.PathValue("id"), format)
+	if err != nil {
+		writeJobError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write([]byte(output))
+}
+
+// writeJobError maps job and validation errors to HTTP status codes
+func writeJobError(w http.ResponseWriter, err error) {
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, controller.ErrInvalidRequest):
+		status = http.StatusBadRequest
+	case errors.Is(err, jobs.ErrNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, jobs.ErrNotFinished), errors.Is(err, jobs.ErrFinished), errors.Is(err, jobs.ErrNoReport):
+		status = http.StatusConflict
+	case errors.Is(err, jobs.ErrQueueFull), errors.Is(err, jobs.ErrShutdown):
+		status = http.StatusServiceUnavailable
+	}
+	writeError(w, status, err.Error())
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]string{"error": message})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		util.Debug("Failed to write API response: %v", err)
+	}
+}
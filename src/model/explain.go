@@ -0,0 +1,40 @@
+package model
+
+// ThresholdCheck is one comparison of an entity metric against a detector
+// threshold. An issue is raised when the value exceeds the threshold.
+type ThresholdCheck struct {
+	Detector  string   `json:"detector"`
+	Rule      string   `json:"rule"` // Subcategory of the issue the check raises
+	Metric    string   `json:"metric"`
+	Value     float64  `json:"value"`
+	Threshold float64  `json:"threshold"`
+	Exceeded  bool     `json:"exceeded"`
+	Severity  Severity `json:"severity,omitempty"` // Severity of the issue, if exceeded
+	Note      string   `json:"note,omitempty"`     // Additional condition or context
+	Disabled  bool     `json:"detector_disabled,omitempty"`
+	RuleOff   bool     `json:"rule_disabled,omitempty"` // Rule disabled for the entity's language
+}
+
+// Explanation shows how detectors judged a single function, class or file
+type Explanation struct {
+	RepoName   string           `json:"repo_name"`
+	EntityType string           `json:"entity_type"`
+	EntityName string           `json:"entity_name"`
+	FilePath   string           `json:"file_path"`
+	Language   string           `json:"language,omitempty"`
+	Profile    string           `json:"profile,omitempty"` // Path profile whose thresholds apply
+	Function   *FunctionMetrics `json:"function,omitempty"`
+	Class      *ClassMetrics    `json:"class,omitempty"`
+	File       *FileMetrics     `json:"file,omitempty"`
+	Checks     []ThresholdCheck `json:"checks"`
+	Exclusions []string         `json:"exclusions,omitempty"` // Exclusion rules matching the entity
+	Issues     []DebtIssue      `json:"issues"`               // Issues raised by detectors, before suppression
+	RawRows    []RawRows        `json:"raw_rows"`
+}
+
+// RawRows holds the CodeAPI rows one metrics query returned for an entity
+type RawRows struct {
+	Kind  string           `json:"kind"`
+	Query string           `json:"query"`
+	Rows  []map[string]any `json:"rows"`
+}
@@ -0,0 +1,26 @@
+package model
+
+// GateResult is the verdict of the quality gate for one analysis run
+type GateResult struct {
+	Passed     bool            `json:"passed"`
+	Conditions []GateCondition `json:"conditions"`
+}
+
+// GateCondition is a single quality gate check
+type GateCondition struct {
+	Name      string  `json:"name"`
+	Threshold float64 `json:"threshold"`
+	Actual    float64 `json:"actual"`
+	Passed    bool    `json:"passed"`
+}
+
+// Failures returns the conditions that did not pass
+func (r *GateResult) Failures() []GateCondition {
+	var failed []GateCondition
+	for _, c := range r.Conditions {
+		if !c.Passed {
+			failed = append(failed, c)
+		}
+	}
+	return failed
+}
@@ -0,0 +1,46 @@
+package model
+
+import "time"
+
+// Snapshot is the persisted record of one analysis run in the history store
+type Snapshot struct {
+	RepoName    string        `json:"repo_name"`
+	Commit      string        `json:"commit,omitempty"`
+	GeneratedAt time.Time     `json:"generated_at"`
+	Summary     ReportSummary `json:"summary"`
+	Issues      []IssueRecord `json:"issues"`
+}
+
+// IssueRecord is the compact form of an issue kept in history
+type IssueRecord struct {
+	Fingerprint string   `json:"fingerprint"`
+	Rule        string   `json:"rule"`
+	Severity    Severity `json:"severity"`
+	FilePath    string   `json:"file_path"`
+}
+
+// TrendReport shows how a repository's debt evolved over recorded runs
+type TrendReport struct {
+	RepoName        string       `json:"repo_name"`
+	Points          []TrendPoint `json:"points"`
+	RegressingFiles []FileTrend  `json:"regressing_files"`
+}
+
+// TrendPoint is the state of a repository at one recorded run
+type TrendPoint struct {
+	Commit      string           `json:"commit,omitempty"`
+	GeneratedAt time.Time        `json:"generated_at"`
+	TotalIssues int              `json:"total_issues"`
+	DebtScore   float64          `json:"debt_score"`
+	DebtGrade   string           `json:"debt_grade,omitempty"`
+	ByCategory  map[Category]int `json:"by_category"`
+	BySeverity  map[Severity]int `json:"by_severity"`
+}
+
+// FileTrend is the change in a file's issue count across the trend window
+type FileTrend struct {
+	FilePath   string `json:"file_path"`
+	FirstCount int    `json:"first_count"`
+	LastCount  int    `json:"last_count"`
+	Delta      int    `json:"delta"`
+}
@@ -1,6 +1,12 @@
 package model
 
-import "time"
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"regexp"
+	"strings"
+	"time"
+)
 
 // Severity represents the severity level of a debt issue
 type Severity string
@@ -12,6 +18,20 @@ const (
 	SeverityCritical Severity = "critical"
 )
 
+// SeverityPriority returns a numeric priority for sorting (lower = more critical)
+func SeverityPriority(s Severity) int {
+	switch s {
+	case SeverityCritical:
+		return 0
+	case SeverityHigh:
+		return 1
+	case SeverityMedium:
+		return 2
+	default:
+		return 3
+	}
+}
+
 // Category represents the category of technical debt
 type Category string
 
@@ -37,23 +57,160 @@ type DebtIssue struct {
 	Metrics     map[string]any `json:"metrics"`
 	Suggestion  string         `json:"suggestion"`
 	CodeSnippet string         `json:"code_snippet,omitempty"` // Optional: actual code
+
+	EffortMinutes int      `json:"effort_minutes,omitempty"` // Estimated remediation effort
+	Owners        []string `json:"owners,omitempty"`         // Owning teams from CODEOWNERS
+	Profile       string   `json:"profile,omitempty"`        // Path profile whose thresholds applied
+}
+
+// RuleID returns the rule identifier of the issue ("category/subcategory")
+func (i DebtIssue) RuleID() string {
+	return string(i.Category) + "/" + i.Subcategory
+}
+
+// digitsPattern matches numbers in issue descriptions, which change with
+// every metric fluctuation and must not affect fingerprints
+var digitsPattern = regexp.MustCompile(`[0-9]+(\.[0-9]+)?`)
+
+// Fingerprint returns a stable identifier for the issue that survives line
+// shifts and metric changes. It is used to match issues across runs.
+func (i DebtIssue) Fingerprint() string {
+	description := digitsPattern.ReplaceAllString(i.Description, "#")
+	key := strings.Join([]string{i.RuleID(), i.FilePath, i.EntityType, i.EntityName, description}, "\x00")
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:16])
 }
 
 // AnalysisReport represents the complete analysis output
 type AnalysisReport struct {
-	RepoName    string        `json:"repo_name"`
-	GeneratedAt time.Time     `json:"generated_at"`
-	Summary     ReportSummary `json:"summary"`
-	Issues      []DebtIssue   `json:"issues"`
+	RepoName    string              `json:"repo_name"`
+	StartedAt   time.Time           `json:"started_at"`
+	GeneratedAt time.Time           `json:"generated_at"`
+	Summary     ReportSummary       `json:"summary"`
+	Issues      []DebtIssue         `json:"issues"`
+	Suppressed  []SuppressedIssue   `json:"suppressed,omitempty"`
+	Scope       *AnalysisScope      `json:"scope,omitempty"`
+	Baseline    *BaselineComparison `json:"baseline,omitempty"`
+	QualityGate *GateResult         `json:"quality_gate,omitempty"`
+}
+
+// AnalysisScope describes a diff-scoped analysis, where only issues on
+// changed code are reported
+type AnalysisScope struct {
+	ChangedSince string `json:"changed_since,omitempty"` // Git ref the diff was taken against
+	ChangedFiles string `json:"changed_files,omitempty"` // Path of the changed files list
+	FileCount    int    `json:"file_count"`              // Number of changed files
+	OutOfScope   int    `json:"out_of_scope"`            // Issues dropped because they are on unchanged code
+}
+
+// SuppressedIssue is an issue silenced by an inline suppression comment.
+// It is kept out of the results but listed in reports for auditing.
+type SuppressedIssue struct {
+	Issue  DebtIssue `json:"issue"`
+	Rule   string    `json:"rule"`   // Rule spec from the annotation ("" = all rules)
+	Reason string    `json:"reason"` // Justification given in the annotation
+	Line   int       `json:"line"`   // Line of the annotation
+}
+
+// BaselineComparison describes how a report differs from a previous report
+type BaselineComparison struct {
+	BaselinePath        string      `json:"baseline_path"`
+	BaselineGeneratedAt time.Time   `json:"baseline_generated_at"`
+	NewIssues           []string    `json:"new_issues"` // Fingerprints of issues absent from the baseline
+	FixedIssues         []DebtIssue `json:"fixed_issues"`
+
+	// Escalated lists matched issues that are more severe than in the baseline
+	Escalated []SeverityChange `json:"escalated_issues,omitempty"`
+}
+
+// SeverityChange records an issue whose severity rose since the baseline
+type SeverityChange struct {
+	Fingerprint string   `json:"fingerprint"`
+	From        Severity `json:"from"`
+	To          Severity `json:"to"`
 }
 
 // ReportSummary contains aggregated statistics
 type ReportSummary struct {
-	TotalIssues  int              `json:"total_issues"`
+	TotalIssues     int              `json:"total_issues"`
+	SuppressedCount int              `json:"suppressed_count"`
+	ByCategory      map[Category]int `json:"by_category"`
+	BySeverity      map[Severity]int `json:"by_severity"`
+	ByOwner         map[string]int   `json:"by_owner,omitempty"`
+	HotspotFiles    []FileHotspot    `json:"hotspot_files"`
+	ChurnHotspots   []ChurnHotspot   `json:"churn_hotspots,omitempty"`
+
+	// Size-normalized debt: severity-weighted issues per 1,000 lines
+	LinesOfCode     int         `json:"lines_of_code"`
+	WeightedIssues  float64     `json:"weighted_issues"`
+	DebtScore       float64     `json:"debt_score"`
+	DebtGrade       string      `json:"debt_grade"`
+	FileScores      []AreaScore `json:"file_scores,omitempty"`
+	DirectoryScores []AreaScore `json:"directory_scores,omitempty"`
+
+	// Directories is the root of the directory tree with subtree totals
+	Directories *DirectoryRollup `json:"directories,omitempty"`
+
+	Remediation *RemediationSummary `json:"remediation,omitempty"`
+}
+
+// AreaScore is the debt score of a single file or directory
+type AreaScore struct {
+	Path           string  `json:"path"`
+	Lines          int     `json:"lines"`
+	WeightedIssues float64 `json:"weighted_issues"`
+	Score          float64 `json:"score"`
+	Grade          string  `json:"grade"`
+}
+
+// DirectoryRollup aggregates the issues and size of a directory and
+// everything below it
+type DirectoryRollup struct {
+	Path           string             `json:"path"` // "." for the repository root
+	Name           string             `json:"name"`
+	TotalIssues    int                `json:"total_issues"`
+	ByCategory     map[Category]int   `json:"by_category"`
+	BySeverity     map[Severity]int   `json:"by_severity"`
+	LinesOfCode    int                `json:"lines_of_code"`
+	WeightedIssues float64            `json:"weighted_issues"`
+	DebtScore      float64            `json:"debt_score"`
+	DebtGrade      string             `json:"debt_grade"`
+	WorstEntity    *EntityRef         `json:"worst_entity,omitempty"`
+	Children       []*DirectoryRollup `json:"children,omitempty"`
+}
+
+// EntityRef points at the code entity of an issue
+type EntityRef struct {
+	Name     string   `json:"name"`
+	Type     string   `json:"type"`
+	FilePath string   `json:"file_path"`
+	Rule     string   `json:"rule"`
+	Severity Severity `json:"severity"`
+}
+
+// RemediationSummary totals the estimated effort to fix all reported issues
+type RemediationSummary struct {
+	TotalMinutes int              `json:"total_minutes"`
 	ByCategory   map[Category]int `json:"by_category"`
 	BySeverity   map[Severity]int `json:"by_severity"`
-	HotspotFiles []FileHotspot    `json:"hotspot_files"`
-	DebtScore    float64          `json:"debt_score"`
+	ByFile       map[string]int   `json:"by_file"`
+
+	// Debt ratio: remediation effort relative to the estimated cost of
+	// developing the analyzed code from scratch
+	LinesOfCode            int     `json:"lines_of_code"`
+	DevelopmentCostMinutes float64 `json:"development_cost_minutes"`
+	DebtRatio              float64 `json:"debt_ratio"` // Percentage; 0 when no line counts are available
+}
+
+// ChurnHotspot is a file ranked by complexity times change frequency
+type ChurnHotspot struct {
+	FilePath    string `json:"file_path"`
+	Commits     int    `json:"commits"`      // Commits touching the file in the history window
+	RecentChurn int    `json:"recent_churn"` // Lines added plus deleted in the recent window
+	Authors     int    `json:"authors"`
+	Complexity  int    `json:"complexity"` // Total cyclomatic complexity of the file
+	IssueCount  int    `json:"issue_count"`
+	Score       int    `json:"score"` // Complexity x commits
 }
 
 // FileHotspot represents a file with many issues
@@ -0,0 +1,40 @@
+package model
+
+import "time"
+
+// PortfolioReport compares the debt of several repositories analyzed in one batch
+type PortfolioReport struct {
+	GeneratedAt time.Time            `json:"generated_at"`
+	Totals      PortfolioTotals      `json:"totals"`
+	Repos       []PortfolioEntry     `json:"repos"`        // Ranked by debt score, worst first; failed repositories last
+	CategoryMix map[Category]float64 `json:"category_mix"` // Percent of all issues per category
+}
+
+// PortfolioTotals aggregates the successfully analyzed repositories
+type PortfolioTotals struct {
+	Analyzed           int     `json:"analyzed"`
+	Failed             int     `json:"failed"`
+	TotalIssues        int     `json:"total_issues"`
+	LinesOfCode        int     `json:"lines_of_code"`
+	DebtScore          float64 `json:"debt_score"`
+	DebtGrade          string  `json:"debt_grade"`
+	RemediationMinutes int     `json:"remediation_minutes"`
+}
+
+// PortfolioEntry is one repository of a portfolio
+type PortfolioEntry struct {
+	Rank               int                  `json:"rank,omitempty"`
+	RepoName           string               `json:"repo_name"`
+	Error              string               `json:"error,omitempty"` // Set if the analysis failed
+	TotalIssues        int                  `json:"total_issues"`
+	LinesOfCode        int                  `json:"lines_of_code"`
+	DebtScore          float64              `json:"debt_score"`
+	DebtGrade          string               `json:"debt_grade,omitempty"`
+	ByCategory         map[Category]int     `json:"by_category,omitempty"`
+	BySeverity         map[Severity]int     `json:"by_severity,omitempty"`
+	CategoryMix        map[Category]float64 `json:"category_mix,omitempty"` // Percent of the repository's issues per category
+	RemediationMinutes int                  `json:"remediation_minutes,omitempty"`
+	DebtRatio          float64              `json:"debt_ratio,omitempty"`
+	GatePassed         *bool                `json:"quality_gate_passed,omitempty"`
+	Reports            []string             `json:"reports,omitempty"` // Paths of the per-repository reports
+}
@@ -0,0 +1,9 @@
+package model
+
+// QueryResult holds the rows of an ad-hoc Cypher query. Columns are ordered
+// as in the query's RETURN clause where possible.
+type QueryResult struct {
+	RepoName string           `json:"repo_name"`
+	Columns  []string         `json:"columns"`
+	Rows     []map[string]any `json:"rows"`
+}
@@ -0,0 +1,153 @@
+package model
+
+// Rule describes a check quality-bot can report, independent of any single
+// finding. Rule IDs are stable and match DebtIssue.RuleID().
+type Rule struct {
+	ID               string   `json:"id"`
+	Name             string   `json:"name"`
+	ShortDescription string   `json:"short_description"`
+	FullDescription  string   `json:"full_description"`
+	Help             string   `json:"help"` // Markdown remediation guidance
+	DefaultSeverity  Severity `json:"default_severity"`
+	Tags             []string `json:"tags"`
+
+	// Remediation cost model: base minutes to fix one occurrence, and the
+	// issue metrics compared against the "threshold" metric to scale it.
+	// The first metric present on an issue is used.
+	EffortMinutes int      `json:"effort_minutes"`
+	EffortMetrics []string `json:"effort_metrics,omitempty"`
+}
+
+// Rules is the catalog of every rule emitted by the built-in detectors
+var Rules = []Rule{
+	{
+		ID:               "complexity/cyclomatic_complexity",
+		Name:             "HighCyclomaticComplexity",
+		ShortDescription: "Function has high cyclomatic complexity",
+		FullDescription:  "The number of independent paths through the function exceeds the configured threshold. Highly branching functions are hard to understand and need many tests to cover.",
+		Help:             "Split the function along its decision points: extract branches into well-named helpers, replace conditional chains with lookup tables or polymorphism, and return early for edge cases.",
+		DefaultSeverity:  SeverityMedium,
+		Tags:             []string{"maintainability", "complexity"},
+		EffortMinutes:    30,
+		EffortMetrics:    []string{"cyclomatic_complexity"},
+	},
+	{
+		ID:               "complexity/deep_nesting",
+		Name:             "DeepNesting",
+		ShortDescription: "Control flow is nested too deeply",
+		FullDescription:  "Conditionals and loops are nested beyond the configured depth, which forces readers to keep many conditions in mind at once.",
+		Help:             "Flatten the structure with guard clauses and early returns, or move inner blocks into separate functions.",
+		DefaultSeverity:  SeverityMedium,
+		Tags:             []string{"maintainability", "complexity"},
+		EffortMinutes:    20,
+		EffortMetrics:    []string{"nesting_depth"},
+	},
+	{
+		ID:               "size/long_method",
+		Name:             "LongMethod",
+		ShortDescription: "Function has too many lines",
+		FullDescription:  "The function body is longer than the configured maximum. Long functions usually do more than one thing and are hard to reuse or test.",
+		Help:             "Extract cohesive blocks into smaller, single-purpose functions with descriptive names.",
+		DefaultSeverity:  SeverityMedium,
+		Tags:             []string{"maintainability", "size"},
+		EffortMinutes:    30,
+		EffortMetrics:    []string{"line_count"},
+	},
+	{
+		ID:               "size/long_parameter_list",
+		Name:             "LongParameterList",
+		ShortDescription: "Function takes too many parameters",
+		FullDescription:  "The function declares more parameters than the configured maximum, which makes call sites error-prone and hints at missing abstractions.",
+		Help:             "Group related parameters into a parameter object or options struct, or use a builder.",
+		DefaultSeverity:  SeverityMedium,
+		Tags:             []string{"maintainability", "size"},
+		EffortMinutes:    15,
+		EffortMetrics:    []string{"parameter_count"},
+	},
+	{
+		ID:               "size/god_class",
+		Name:             "GodClass",
+		ShortDescription: "Class has too many methods or fields",
+		FullDescription:  "The class exceeds the configured method or field count. Classes this large tend to accumulate unrelated responsibilities.",
+		Help:             "Identify groups of methods and fields that change together and extract them into focused classes (Single Responsibility Principle).",
+		DefaultSeverity:  SeverityMedium,
+		Tags:             []string{"maintainability", "size", "design"},
+		EffortMinutes:    120,
+		EffortMetrics:    []string{"method_count", "field_count"},
+	},
+	{
+		ID:               "size/large_file",
+		Name:             "LargeFile",
+		ShortDescription: "File has too many lines or functions",
+		FullDescription:  "The file exceeds the configured line or function count, which makes it hard to navigate and a frequent source of merge conflicts.",
+		Help:             "Split the file by responsibility, feature or domain concept.",
+		DefaultSeverity:  SeverityLow,
+		Tags:             []string{"maintainability", "size"},
+		EffortMinutes:    60,
+		EffortMetrics:    []string{"line_count", "function_count"},
+	},
+	{
+		ID:               "coupling/feature_envy",
+		Name:             "FeatureEnvy",
+		ShortDescription: "Method uses another class's data more than its own",
+		FullDescription:  "The method accesses external fields considerably more often than fields of its own class, suggesting it belongs elsewhere.",
+		Help:             "Move the method, or the part of it that works on the foreign data, to the class that owns that data.",
+		DefaultSeverity:  SeverityMedium,
+		Tags:             []string{"maintainability", "coupling", "design"},
+		EffortMinutes:    20,
+		EffortMetrics:    []string{"external_field_uses"},
+	},
+	{
+		ID:               "coupling/high_coupling",
+		Name:             "HighCoupling",
+		ShortDescription: "Class depends on too many other classes",
+		FullDescription:  "The class depends on more classes than the configured maximum, so changes elsewhere are likely to ripple into it.",
+		Help:             "Introduce interfaces or facades at module boundaries and move responsibilities so each class talks to fewer collaborators.",
+		DefaultSeverity:  SeverityMedium,
+		Tags:             []string{"maintainability", "coupling", "design"},
+		EffortMinutes:    60,
+		EffortMetrics:    []string{"dependency_count"},
+	},
+	{
+		ID:               "coupling/inappropriate_intimacy",
+		Name:             "InappropriateIntimacy",
+		ShortDescription: "Two classes call each other heavily",
+		FullDescription:  "A pair of classes calls into each other in both directions beyond the configured threshold, so neither can change independently.",
+		Help:             "Extract the shared logic into a new class, or merge the two classes if they really are one concept.",
+		DefaultSeverity:  SeverityMedium,
+		Tags:             []string{"maintainability", "coupling", "design"},
+		EffortMinutes:    90,
+		EffortMetrics:    []string{"calls_1_to_2"},
+	},
+	{
+		ID:               "coupling/primitive_obsession",
+		Name:             "PrimitiveObsession",
+		ShortDescription: "Class relies on too many primitive fields",
+		FullDescription:  "A large share of the class's fields are primitives, which often means domain concepts are spread across loose values instead of types.",
+		Help:             "Introduce value objects or domain types for related primitives and move their validation there.",
+		DefaultSeverity:  SeverityLow,
+		Tags:             []string{"maintainability", "coupling", "design"},
+		EffortMinutes:    45,
+		EffortMetrics:    []string{"primitive_field_count"},
+	},
+	{
+		ID:               "duplication/similar_code",
+		Name:             "SimilarCode",
+		ShortDescription: "Function is highly similar to another function",
+		FullDescription:  "The function body is near-identical to another function in the repository, so fixes have to be applied in several places.",
+		Help:             "Extract the common logic into a shared function and parameterize the differences.",
+		DefaultSeverity:  SeverityMedium,
+		Tags:             []string{"maintainability", "duplication"},
+		EffortMinutes:    30,
+	},
+}
+
+// LookupRule returns the catalog entry for a rule ID
+func LookupRule(id string) (Rule, bool) {
+	for _, r := range Rules {
+		if r.ID == id {
+			return r, true
+		}
+	}
+	return Rule{}, false
+}
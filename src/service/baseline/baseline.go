@@ -0,0 +1,88 @@
+package baseline
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"quality-bot/src/model"
+	"quality-bot/src/util"
+)
+
+// Load reads a previously generated JSON report to compare against
+func Load(path string) (*model.AnalysisReport, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading baseline report: %w", err)
+	}
+
+	var report model.AnalysisReport
+	if err := json.Unmarshal(data, &report); err != nil {
+		return nil, fmt.Errorf("parsing baseline report: %w", err)
+	}
+
+	if report.Summary.TotalIssues > len(report.Issues) {
+		util.Warn("Baseline report %s lists %d of its %d issues (output.max_issues_per_category); unlisted issues count as new. Write baselines with max_issues_per_category: 0",
+			path, len(report.Issues), report.Summary.TotalIssues)
+	}
+
+	util.Debug("Loaded baseline report %s (%d issues, generated %s)", path, len(report.Issues), report.GeneratedAt)
+	return &report, nil
+}
+
+// Compare matches issues by fingerprint and returns which issues are new in
+// current, which baseline issues are gone and which matched issues became
+// more severe. Fingerprints are counted so that repeated findings on the same
+// entity are matched one-to-one; fingerprints ignore severity, so each match
+// takes the most severe baseline issue left to avoid reporting false
+// escalations.
+func Compare(current, base *model.AnalysisReport, basePath string) *model.BaselineComparison {
+	remaining := make(map[string][]model.Severity)
+	for _, issue := range base.Issues {
+		fp := issue.Fingerprint()
+		remaining[fp] = append(remaining[fp], issue.Severity)
+	}
+
+	comparison := &model.BaselineComparison{
+		BaselinePath:        basePath,
+		BaselineGeneratedAt: base.GeneratedAt,
+		NewIssues:           []string{},
+		FixedIssues:         []model.DebtIssue{},
+	}
+
+	currentCounts := make(map[string]int)
+	for _, issue := range current.Issues {
+		fp := issue.Fingerprint()
+		currentCounts[fp]++
+		severities := remaining[fp]
+		if len(severities) == 0 {
+			comparison.NewIssues = append(comparison.NewIssues, fp)
+			continue
+		}
+
+		worst := 0
+		for i, s := range severities {
+			if model.SeverityPriority(s) < model.SeverityPriority(severities[worst]) {
+				worst = i
+			}
+		}
+		was := severities[worst]
+		remaining[fp] = append(severities[:worst], severities[worst+1:]...)
+		if model.SeverityPriority(issue.Severity) < model.SeverityPriority(was) {
+			comparison.Escalated = append(comparison.Escalated, model.SeverityChange{Fingerprint: fp, From: was, To: issue.Severity})
+		}
+	}
+
+	for _, issue := range base.Issues {
+		fp := issue.Fingerprint()
+		if currentCounts[fp] > 0 {
+			currentCounts[fp]--
+			continue
+		}
+		comparison.FixedIssues = append(comparison.FixedIssues, issue)
+	}
+
+	util.Debug("Baseline comparison: %d new, %d fixed, %d escalated",
+		len(comparison.NewIssues), len(comparison.FixedIssues), len(comparison.Escalated))
+	return comparison
+}
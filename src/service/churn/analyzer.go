@@ -0,0 +1,177 @@
+package churn
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"quality-bot/src/config"
+	"quality-bot/src/model"
+	"quality-bot/src/service/vcs"
+	"quality-bot/src/util"
+)
+
+// commitMarker prefixes the header line git prints for every commit; the
+// format string passes it to git as "%x00commit"
+const commitMarker = "\x00commit"
+
+// FileChurn is the change history of a single file
+type FileChurn struct {
+	Path        string
+	Commits     int
+	RecentChurn int // Lines added plus deleted within the recent window
+	Authors     int
+}
+
+// History holds the churn of every file touched in the analyzed window
+type History struct {
+	files map[string]*FileChurn
+}
+
+// Analyze reads `git log --numstat` in the checkout at dir, with paths
+// relative to dir. Merge commits are skipped since their changes are already
+// counted on the merged branch.
+func Analyze(ctx context.Context, dir string, cfg config.ChurnConfig) (*History, error) {
+	args := []string{"log", "--numstat", "--relative", "--no-merges", "--format=%x00commit %at %ae"}
+	if cfg.Since != "" {
+		args = append(args, "--since="+cfg.Since)
+	}
+
+	out, err := vcs.Git(ctx, dir, args...)
+	if err != nil {
+		return nil, fmt.Errorf("reading git history: %w", err)
+	}
+
+	recentCutoff := time.Now().AddDate(0, 0, -cfg.RecentDays)
+	h := Parse(out, recentCutoff)
+	util.Debug("Churn: %d files changed in git history of %s", len(h.files), dir)
+	return h, nil
+}
+
+// Parse builds the churn history from `git log --numstat` output in the
+// format written by Analyze
+func Parse(log string, recentCutoff time.Time) *History {
+	h := &History{files: make(map[string]*FileChurn)}
+	authors := make(map[string]map[string]bool)
+
+	var (
+		author string
+		recent bool
+	)
+
+	scanner := bufio.NewScanner(strings.NewReader(log))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		if strings.HasPrefix(line, commitMarker) {
+			fields := strings.Fields(strings.TrimPrefix(line, commitMarker))
+			author = ""
+			recent = false
+			if len(fields) >= 1 {
+				if ts, err := strconv.ParseInt(fields[0], 10, 64); err == nil {
+					recent = !time.Unix(ts, 0).Before(recentCutoff)
+				}
+			}
+			if len(fields) >= 2 {
+				author = fields[1]
+			}
+			continue
+		}
+
+		// numstat: "<added>\t<deleted>\t<path>"; binary files show "-"
+		parts := strings.SplitN(line, "\t", 3)
+		if len(parts) != 3 {
+			continue
+		}
+		path := renamedPath(parts[2])
+
+		fc, ok := h.files[path]
+		if !ok {
+			fc = &FileChurn{Path: path}
+			h.files[path] = fc
+			authors[path] = make(map[string]bool)
+		}
+		fc.Commits++
+		if author != "" && !authors[path][author] {
+			authors[path][author] = true
+			fc.Authors++
+		}
+		if recent {
+			added, _ := strconv.Atoi(parts[0])
+			deleted, _ := strconv.Atoi(parts[1])
+			fc.RecentChurn += added + deleted
+		}
+	}
+
+	return h
+}
+
+// braceRename matches the compact rename notation "dir/{old => new}/file"
+var braceRename = regexp.MustCompile(`\{([^{}]*) => ([^{}]*)\}`)
+
+// renamedPath returns the new path of a numstat entry, resolving rename
+// notations ("old => new" and "dir/{old => new}/file")
+func renamedPath(path string) string {
+	if m := braceRename.FindStringSubmatchIndex(path); m != nil {
+		newPart := path[m[4]:m[5]]
+		path = path[:m[0]] + newPart + path[m[1]:]
+		return strings.ReplaceAll(path, "//", "/")
+	}
+	if i := strings.Index(path, " => "); i >= 0 {
+		return path[i+len(" => "):]
+	}
+	return path
+}
+
+// Lookup returns the churn of a file by its repo-relative path, as both git
+// and CodeAPI report it. Only exact matches count: "a.go" must not match
+// "pkg/a.go".
+func (h *History) Lookup(filePath string) (*FileChurn, bool) {
+	fc, ok := h.files[strings.TrimPrefix(strings.TrimPrefix(filePath, "./"), "/")]
+	return fc, ok
+}
+
+// Hotspots ranks files by total cyclomatic complexity times commit count:
+// complex code that changes often is where debt costs the most. Files without
+// complexity or without commits in the window are not hotspots.
+func (h *History) Hotspots(files []model.FileMetrics, issues []model.DebtIssue, topN int) []model.ChurnHotspot {
+	issueCount := make(map[string]int)
+	for _, issue := range issues {
+		issueCount[issue.FilePath]++
+	}
+
+	var hotspots []model.ChurnHotspot
+	for _, f := range files {
+		fc, ok := h.Lookup(f.Path)
+		if !ok || f.TotalCyclomaticComplexity == 0 {
+			continue
+		}
+		hotspots = append(hotspots, model.ChurnHotspot{
+			FilePath:    f.Path,
+			Commits:     fc.Commits,
+			RecentChurn: fc.RecentChurn,
+			Authors:     fc.Authors,
+			Complexity:  f.TotalCyclomaticComplexity,
+			IssueCount:  issueCount[f.Path],
+			Score:       f.TotalCyclomaticComplexity * fc.Commits,
+		})
+	}
+
+	sort.Slice(hotspots, func(i, j int) bool {
+		if hotspots[i].Score != hotspots[j].Score {
+			return hotspots[i].Score > hotspots[j].Score
+		}
+		return hotspots[i].FilePath < hotspots[j].FilePath
+	})
+
+	if topN > 0 && len(hotspots) > topN {
+		hotspots = hotspots[:topN]
+	}
+	return hotspots
+}
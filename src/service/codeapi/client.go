@@ -7,10 +7,11 @@ import (
 	"fmt"
 	"io"
 	"net/http"
-	"strings"
+	"strconv"
 	"time"
 
 	"quality-bot/src/config"
+	"quality-bot/src/service/telemetry"
 	"quality-bot/src/util"
 )
 
@@ -32,13 +33,24 @@ func NewClient(cfg config.CodeAPIConfig) *Client {
 	}
 }
 
-// ExecuteCypher executes a Cypher query against the code graph
+// ExecuteCypher executes a Cypher query against the code graph, with
+// $repo_name bound to the repository
 func (c *Client) ExecuteCypher(ctx context.Context, repoName, query string) ([]map[string]any, error) {
+	return c.ExecuteCypherWithParams(ctx, repoName, query, nil)
+}
+
+// ExecuteCypherWithParams executes a Cypher query with named parameters.
+// $repo_name is always bound to the repository; any other $name in the query
+// must be in params.
+func (c *Client) ExecuteCypherWithParams(ctx context.Context, repoName, query string, params map[string]any) ([]map[string]any, error) {
 	util.Debug("Executing Cypher query for repo: %s", repoName)
 
-	// Replace $repo_name parameter with quoted literal since CodeAPI
-	// doesn't support passing parameters separately
-	resolvedQuery := strings.ReplaceAll(query, "$repo_name", fmt.Sprintf("'%s'", repoName))
+	// Replace parameters with literals since CodeAPI doesn't support
+	// passing them separately
+	resolvedQuery, err := bindParameters(query, repoName, params)
+	if err != nil {
+		return nil, err
+	}
 
 	req := CypherRequest{
 		RepoName: repoName,
@@ -111,6 +123,7 @@ func (c *Client) post(ctx context.Context, path string, body any, result any) er
 		if attempt > 0 {
 			delay := c.calculateBackoff(attempt)
 			util.Warn("Retrying request to %s (attempt %d/%d) after %v", path, attempt+1, c.retryConf.MaxAttempts, delay)
+			telemetry.CountCodeAPIRetry(path)
 			select {
 			case <-ctx.Done():
 				return ctx.Err()
@@ -118,7 +131,13 @@ func (c *Client) post(ctx context.Context, path string, body any, result any) er
 			}
 		}
 
-		err := c.doPost(ctx, path, body, result)
+		start := time.Now()
+		status, err := c.doPost(ctx, path, body, result)
+		code := "error"
+		if status > 0 {
+			code = strconv.Itoa(status)
+		}
+		telemetry.ObserveCodeAPIRequest(path, code, time.Since(start))
 		if err == nil {
 			return nil
 		}
@@ -129,37 +148,40 @@ func (c *Client) post(ctx context.Context, path string, body any, result any) er
 		}
 	}
 
+	telemetry.CountCodeAPIError(path)
 	return lastErr
 }
 
-func (c *Client) doPost(ctx context.Context, path string, body any, result any) error {
+// doPost sends a single request and returns the HTTP status, or 0 if no
+// response was received
+func (c *Client) doPost(ctx context.Context, path string, body any, result any) (int, error) {
 	jsonBody, err := json.Marshal(body)
 	if err != nil {
-		return fmt.Errorf("marshaling request: %w", err)
+		return 0, fmt.Errorf("marshaling request: %w", err)
 	}
 
 	req, err := http.NewRequestWithContext(ctx, "POST", c.baseURL+path, bytes.NewReader(jsonBody))
 	if err != nil {
-		return fmt.Errorf("creating request: %w", err)
+		return 0, fmt.Errorf("creating request: %w", err)
 	}
 	req.Header.Set("Content-Type", "application/json")
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		return fmt.Errorf("executing request: %w", err)
+		return 0, fmt.Errorf("executing request: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode >= 400 {
 		respBody, _ := io.ReadAll(resp.Body)
-		return &APIError{StatusCode: resp.StatusCode, Body: string(respBody)}
+		return resp.StatusCode, &APIError{StatusCode: resp.StatusCode, Body: string(respBody)}
 	}
 
 	if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
-		return fmt.Errorf("decoding response: %w", err)
+		return resp.StatusCode, fmt.Errorf("decoding response: %w", err)
 	}
 
-	return nil
+	return resp.StatusCode, nil
 }
 
 func (c *Client) calculateBackoff(attempt int) time.Duration {
@@ -0,0 +1,138 @@
+package codeapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// paramPattern matches a Cypher parameter reference such as $repo_name at the
+// start of the input
+var paramPattern = regexp.MustCompile(`^\$([A-Za-z_][A-Za-z0-9_]*)`)
+
+// bindParameters replaces every $name in query with the literal of its value.
+// References inside string literals, backquoted names and comments are left
+// alone. $repo_name defaults to the repository; unbound parameters are an
+// error.
+func bindParameters(query, repoName string, params map[string]any) (string, error) {
+	var (
+		missing  = make(map[string]bool)
+		resolved strings.Builder
+	)
+
+	for i := 0; i < len(query); {
+		if end := skipVerbatim(query, i); end > i {
+			resolved.WriteString(query[i:end])
+			i = end
+			continue
+		}
+
+		m := paramPattern.FindStringSubmatch(query[i:])
+		if m == nil {
+			resolved.WriteByte(query[i])
+			i++
+			continue
+		}
+		i += len(m[0])
+
+		name := m[1]
+		value, ok := params[name]
+		if !ok && name == "repo_name" {
+			value, ok = repoName, true
+		}
+		if !ok {
+			missing[name] = true
+			resolved.WriteString(m[0])
+			continue
+		}
+		literal, err := cypherLiteral(value)
+		if err != nil {
+			return "", fmt.Errorf("parameter $%s: %w", name, err)
+		}
+		resolved.WriteString(literal)
+	}
+
+	if len(missing) > 0 {
+		names := make([]string, 0, len(missing))
+		for name := range missing {
+			names = append(names, "$"+name)
+		}
+		sort.Strings(names)
+		return "", fmt.Errorf("unbound query parameters: %s", strings.Join(names, ", "))
+	}
+	return resolved.String(), nil
+}
+
+// skipVerbatim returns the end of the string literal, backquoted name or
+// comment starting at i, or i if none starts there. Unterminated ones run to
+// the end of the query.
+func skipVerbatim(query string, i int) int {
+	switch {
+	case query[i] == '\'' || query[i] == '"' || query[i] == '`':
+		quote := query[i]
+		for j := i + 1; j < len(query); j++ {
+			switch {
+			case query[j] == '\\' && quote != '`':
+				j++ // Escaped character
+			case query[j] == quote:
+				return j + 1
+			}
+		}
+		return len(query)
+	case strings.HasPrefix(query[i:], "//"):
+		if end := strings.IndexByte(query[i:], '\n'); end >= 0 {
+			return i + end
+		}
+		return len(query)
+	case strings.HasPrefix(query[i:], "/*"):
+		if end := strings.Index(query[i+2:], "*/"); end >= 0 {
+			return i + 2 + end + 2
+		}
+		return len(query)
+	}
+	return i
+}
+
+// cypherLiteral renders a value as a Cypher literal: strings are quoted and
+// escaped, lists are rendered element by element
+func cypherLiteral(value any) (string, error) {
+	switch v := value.(type) {
+	case nil:
+		return "null", nil
+	case string:
+		escaped := strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(v)
+		return "'" + escaped + "'", nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	case int:
+		return strconv.Itoa(v), nil
+	case int64:
+		return strconv.FormatInt(v, 10), nil
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), nil
+	case json.Number:
+		// Rendered as given so large integers keep their precision
+		return v.String(), nil
+	case []string:
+		items := make([]any, len(v))
+		for i, s := range v {
+			items[i] = s
+		}
+		return cypherLiteral(items)
+	case []any:
+		parts := make([]string, len(v))
+		for i, item := range v {
+			literal, err := cypherLiteral(item)
+			if err != nil {
+				return "", err
+			}
+			parts[i] = literal
+		}
+		return "[" + strings.Join(parts, ", ") + "]", nil
+	default:
+		return "", fmt.Errorf("unsupported value type %T", value)
+	}
+}
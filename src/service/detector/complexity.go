@@ -50,30 +50,60 @@ func (d *ComplexityDetector) Detect(ctx context.Context) ([]model.DebtIssue, err
 			excluded++
 			continue
 		}
+		cfg, _ := d.settings(fn.FilePath)
 
 		// Check cyclomatic complexity
-		if fn.CyclomaticComplexity > d.cfg.CyclomaticModerate {
+		if fn.CyclomaticComplexity > cfg.CyclomaticModerate {
 			issues = append(issues, d.createCCIssue(fn))
 		}
 
 		// Check nesting depth
-		if fn.MaxNestingDepth > d.cfg.MaxNestingDepth {
+		if fn.MaxNestingDepth > cfg.MaxNestingDepth {
 			issues = append(issues, d.createNestingIssue(fn))
 		}
 	}
 
 	util.Debug("Complexity detector: %d functions excluded by filters", excluded)
-	return d.FilterBySeverity(issues), nil
+	return d.FilterBySeverity(d.FilterDisabledRules(issues)), nil
+}
+
+// settings returns the complexity thresholds in effect for a file and the
+// profile they come from
+func (d *ComplexityDetector) settings(filePath string) (config.ComplexityDetectorConfig, string) {
+	settings := d.Settings(filePath)
+	return settings.Detectors.Complexity, settings.Profile
+}
+
+// FunctionChecks returns the complexity checks run on a function
+func (d *ComplexityDetector) FunctionChecks(fn model.FunctionMetrics) []model.ThresholdCheck {
+	cfg, _ := d.settings(fn.FilePath)
+	return []model.ThresholdCheck{
+		newCheck(d.Name(), "cyclomatic_complexity", "cyclomatic_complexity", fn.CyclomaticComplexity, cfg.CyclomaticModerate,
+			func() model.DebtIssue { return d.createCCIssue(fn) }),
+		newCheck(d.Name(), "deep_nesting", "max_nesting_depth", fn.MaxNestingDepth, cfg.MaxNestingDepth,
+			func() model.DebtIssue { return d.createNestingIssue(fn) }),
+	}
+}
+
+// ClassChecks returns nothing; complexity is checked per function
+func (d *ComplexityDetector) ClassChecks(model.ClassMetrics, []model.ClassPairMetrics) []model.ThresholdCheck {
+	return nil
+}
+
+// FileChecks returns nothing; complexity is checked per function
+func (d *ComplexityDetector) FileChecks(model.FileMetrics) []model.ThresholdCheck {
+	return nil
 }
 
 func (d *ComplexityDetector) createCCIssue(fn model.FunctionMetrics) model.DebtIssue {
 	cc := fn.CyclomaticComplexity
+	cfg, profile := d.settings(fn.FilePath)
 
 	var severity model.Severity
 	switch {
-	case cc > d.cfg.CyclomaticCritical:
+	case cc > cfg.CyclomaticCritical:
 		severity = model.SeverityCritical
-	case cc > d.cfg.CyclomaticHigh:
+	case cc > cfg.CyclomaticHigh:
 		severity = model.SeverityHigh
 	default:
 		severity = model.SeverityMedium
@@ -94,13 +124,16 @@ func (d *ComplexityDetector) createCCIssue(fn model.FunctionMetrics) model.DebtI
 			"conditionals":          fn.ConditionalCount,
 			"loops":                 fn.LoopCount,
 			"branches":              fn.BranchCount,
+			"threshold":             cfg.CyclomaticModerate,
 		},
-		Suggestion: d.ccSuggestion(cc),
+		Suggestion: ccSuggestion(cc, cfg),
+		Profile:    profile,
 	}
 }
 
 func (d *ComplexityDetector) createNestingIssue(fn model.FunctionMetrics) model.DebtIssue {
 	depth := fn.MaxNestingDepth
+	cfg, profile := d.settings(fn.FilePath)
 
 	var severity model.Severity
 	switch {
@@ -124,16 +157,18 @@ func (d *ComplexityDetector) createNestingIssue(fn model.FunctionMetrics) model.
 		Description: fmt.Sprintf("Deeply nested control flow (depth=%d)", depth),
 		Metrics: map[string]any{
 			"nesting_depth": depth,
+			"threshold":     cfg.MaxNestingDepth,
 		},
 		Suggestion: "Reduce nesting with early returns, guard clauses, or extract methods",
+		Profile:    profile,
 	}
 }
 
-func (d *ComplexityDetector) ccSuggestion(cc int) string {
+func ccSuggestion(cc int, cfg config.ComplexityDetectorConfig) string {
 	switch {
-	case cc > d.cfg.CyclomaticCritical:
+	case cc > cfg.CyclomaticCritical:
 		return "Split into multiple smaller functions; consider strategy or state pattern"
-	case cc > d.cfg.CyclomaticHigh:
+	case cc > cfg.CyclomaticHigh:
 		return "Extract conditional logic into separate methods"
 	default:
 		return "Consider simplifying conditionals or extracting helper methods"
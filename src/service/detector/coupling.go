@@ -70,7 +70,7 @@ func (d *CouplingDetector) Detect(ctx context.Context) ([]model.DebtIssue, error
 	issues = append(issues, primitiveIssues...)
 	util.Debug("Coupling detector: found %d primitive obsession issues", len(primitiveIssues))
 
-	return d.FilterBySeverity(issues), nil
+	return d.FilterBySeverity(d.FilterDisabledRules(issues)), nil
 }
 
 func (d *CouplingDetector) detectFeatureEnvy(ctx context.Context) ([]model.DebtIssue, error) {
@@ -86,34 +86,8 @@ func (d *CouplingDetector) detectFeatureEnvy(ctx context.Context) ([]model.DebtI
 			continue
 		}
 
-		// Feature envy: method uses external fields more than its own class fields
-		if fn.ClassName != "" &&
-			fn.ExternalFieldUses > fn.OwnFieldUses &&
-			fn.ExternalFieldUses > d.cfg.FeatureEnvyThreshold {
-
-			severity := model.SeverityMedium
-			ratio := float64(fn.ExternalFieldUses) / float64(max(fn.OwnFieldUses, 1))
-			if ratio > 3 {
-				severity = model.SeverityHigh
-			}
-
-			issues = append(issues, model.DebtIssue{
-				Category:    model.CategoryCoupling,
-				Subcategory: "feature_envy",
-				Severity:    severity,
-				FilePath:    fn.FilePath,
-				StartLine:   fn.StartLine,
-				EndLine:     fn.EndLine,
-				EntityName:  fn.Name,
-				EntityType:  "function",
-				Description: fmt.Sprintf("Method uses %d external fields vs %d own fields", fn.ExternalFieldUses, fn.OwnFieldUses),
-				Metrics: map[string]any{
-					"external_field_uses": fn.ExternalFieldUses,
-					"own_field_uses":      fn.OwnFieldUses,
-					"ratio":               ratio,
-				},
-				Suggestion: "Consider moving this method to the class whose data it uses most",
-			})
+		if d.isFeatureEnvy(fn) {
+			issues = append(issues, d.createFeatureEnvyIssue(fn))
 		}
 	}
 
@@ -133,28 +107,8 @@ func (d *CouplingDetector) detectHighCoupling(ctx context.Context) ([]model.Debt
 			continue
 		}
 
-		if cls.DependencyCount > d.cfg.MaxDependencies {
-			severity := model.SeverityMedium
-			if cls.DependencyCount > d.cfg.MaxDependencies*2 {
-				severity = model.SeverityHigh
-			}
-
-			issues = append(issues, model.DebtIssue{
-				Category:    model.CategoryCoupling,
-				Subcategory: "high_coupling",
-				Severity:    severity,
-				FilePath:    cls.FilePath,
-				StartLine:   cls.StartLine,
-				EndLine:     cls.EndLine,
-				EntityName:  cls.Name,
-				EntityType:  "class",
-				Description: fmt.Sprintf("Class depends on %d other classes (threshold: %d)", cls.DependencyCount, d.cfg.MaxDependencies),
-				Metrics: map[string]any{
-					"dependency_count": cls.DependencyCount,
-					"threshold":        d.cfg.MaxDependencies,
-				},
-				Suggestion: "Reduce dependencies by introducing abstractions or reorganizing responsibilities",
-			})
+		if cfg, _ := d.settings(cls.FilePath); cls.DependencyCount > cfg.MaxDependencies {
+			issues = append(issues, d.createHighCouplingIssue(cls))
 		}
 	}
 
@@ -177,9 +131,7 @@ func (d *CouplingDetector) detectInappropriateIntimacy(ctx context.Context) ([]m
 			continue
 		}
 
-		// Check for inappropriate intimacy (bidirectional high coupling)
-		if pair.Calls1To2 > d.cfg.IntimacyCallThreshold &&
-			pair.Calls2To1 > d.cfg.IntimacyCallThreshold {
+		if d.isIntimate(pair) {
 
 			// Create a unique key to avoid duplicate reports
 			key := pair.Class1Name + ":" + pair.Class2Name
@@ -192,27 +144,7 @@ func (d *CouplingDetector) detectInappropriateIntimacy(ctx context.Context) ([]m
 			}
 			reported[key] = true
 
-			severity := model.SeverityHigh // Bidirectional is always more serious
-
-			issues = append(issues, model.DebtIssue{
-				Category:    model.CategoryCoupling,
-				Subcategory: "inappropriate_intimacy",
-				Severity:    severity,
-				FilePath:    pair.Class1File,
-				StartLine:   1,
-				EndLine:     1,
-				EntityName:  fmt.Sprintf("%s <-> %s", pair.Class1Name, pair.Class2Name),
-				EntityType:  "class_pair",
-				Description: fmt.Sprintf("Classes are too tightly coupled (%d calls each way)", min(pair.Calls1To2, pair.Calls2To1)),
-				Metrics: map[string]any{
-					"calls_1_to_2":        pair.Calls1To2,
-					"calls_2_to_1":        pair.Calls2To1,
-					"shared_field_access": pair.SharedFieldAccess,
-					"class1":              pair.Class1Name,
-					"class2":              pair.Class2Name,
-				},
-				Suggestion: "Extract shared logic into a new class or merge if appropriate",
-			})
+			issues = append(issues, d.createIntimacyIssue(pair))
 		}
 	}
 
@@ -232,26 +164,194 @@ func (d *CouplingDetector) detectPrimitiveObsession(ctx context.Context) ([]mode
 			continue
 		}
 
-		if cls.PrimitiveFieldCount > d.cfg.PrimitiveFieldThreshold {
-			issues = append(issues, model.DebtIssue{
-				Category:    model.CategoryCoupling,
-				Subcategory: "primitive_obsession",
-				Severity:    model.SeverityMedium,
-				FilePath:    cls.FilePath,
-				StartLine:   cls.StartLine,
-				EndLine:     cls.EndLine,
-				EntityName:  cls.Name,
-				EntityType:  "class",
-				Description: fmt.Sprintf("Class has %d primitive fields (threshold: %d)", cls.PrimitiveFieldCount, d.cfg.PrimitiveFieldThreshold),
-				Metrics: map[string]any{
-					"primitive_field_count": cls.PrimitiveFieldCount,
-					"total_field_count":     cls.FieldCount,
-					"threshold":             d.cfg.PrimitiveFieldThreshold,
-				},
-				Suggestion: "Consider creating value objects or domain types for related primitives",
-			})
+		if cfg, _ := d.settings(cls.FilePath); cls.PrimitiveFieldCount > cfg.PrimitiveFieldThreshold {
+			issues = append(issues, d.createPrimitiveObsessionIssue(cls))
 		}
 	}
 
 	return issues, nil
 }
+
+// settings returns the coupling thresholds in effect for a file and the
+// profile they come from. Class pairs use the settings of the first class.
+func (d *CouplingDetector) settings(filePath string) (config.CouplingDetectorConfig, string) {
+	settings := d.Settings(filePath)
+	return settings.Detectors.Coupling, settings.Profile
+}
+
+// isFeatureEnvy reports whether a method uses external fields more than its
+// own class fields
+func (d *CouplingDetector) isFeatureEnvy(fn model.FunctionMetrics) bool {
+	cfg, _ := d.settings(fn.FilePath)
+	return fn.ClassName != "" &&
+		fn.ExternalFieldUses > fn.OwnFieldUses &&
+		fn.ExternalFieldUses > cfg.FeatureEnvyThreshold
+}
+
+// isIntimate reports inappropriate intimacy (bidirectional high coupling)
+func (d *CouplingDetector) isIntimate(pair model.ClassPairMetrics) bool {
+	cfg, _ := d.settings(pair.Class1File)
+	return pair.Calls1To2 > cfg.IntimacyCallThreshold &&
+		pair.Calls2To1 > cfg.IntimacyCallThreshold
+}
+
+// FunctionChecks returns the coupling checks run on a function
+func (d *CouplingDetector) FunctionChecks(fn model.FunctionMetrics) []model.ThresholdCheck {
+	cfg, _ := d.settings(fn.FilePath)
+	check := model.ThresholdCheck{
+		Detector:  d.Name(),
+		Rule:      "feature_envy",
+		Metric:    "external_field_uses",
+		Value:     float64(fn.ExternalFieldUses),
+		Threshold: float64(cfg.FeatureEnvyThreshold),
+		Exceeded:  d.isFeatureEnvy(fn),
+		Note:      fmt.Sprintf("also requires a containing class and more external than own field uses (%d)", fn.OwnFieldUses),
+	}
+	if check.Exceeded {
+		check.Severity = d.createFeatureEnvyIssue(fn).Severity
+	}
+	return []model.ThresholdCheck{check}
+}
+
+// ClassChecks returns the coupling checks run on a class and its class pairs
+func (d *CouplingDetector) ClassChecks(cls model.ClassMetrics, pairs []model.ClassPairMetrics) []model.ThresholdCheck {
+	cfg, _ := d.settings(cls.FilePath)
+	checks := []model.ThresholdCheck{
+		newCheck(d.Name(), "high_coupling", "dependency_count", cls.DependencyCount, cfg.MaxDependencies,
+			func() model.DebtIssue { return d.createHighCouplingIssue(cls) }),
+		newCheck(d.Name(), "primitive_obsession", "primitive_field_count", cls.PrimitiveFieldCount, cfg.PrimitiveFieldThreshold,
+			func() model.DebtIssue { return d.createPrimitiveObsessionIssue(cls) }),
+	}
+
+	for _, pair := range pairs {
+		other := pair.Class2Name
+		if pair.Class2Name == cls.Name && pair.Class2File == cls.FilePath {
+			other = pair.Class1Name
+		}
+		pairCfg, _ := d.settings(pair.Class1File)
+		check := model.ThresholdCheck{
+			Detector:  d.Name(),
+			Rule:      "inappropriate_intimacy",
+			Metric:    "calls_each_way",
+			Value:     float64(min(pair.Calls1To2, pair.Calls2To1)),
+			Threshold: float64(pairCfg.IntimacyCallThreshold),
+			Exceeded:  d.isIntimate(pair),
+			Note: fmt.Sprintf("with %s (%s -> %s: %d calls, %s -> %s: %d calls)", other,
+				pair.Class1Name, pair.Class2Name, pair.Calls1To2, pair.Class2Name, pair.Class1Name, pair.Calls2To1),
+		}
+		if check.Exceeded {
+			check.Severity = d.createIntimacyIssue(pair).Severity
+		}
+		checks = append(checks, check)
+	}
+	return checks
+}
+
+// FileChecks returns nothing; coupling is checked per function and class
+func (d *CouplingDetector) FileChecks(model.FileMetrics) []model.ThresholdCheck {
+	return nil
+}
+
+func (d *CouplingDetector) createFeatureEnvyIssue(fn model.FunctionMetrics) model.DebtIssue {
+	cfg, profile := d.settings(fn.FilePath)
+	severity := model.SeverityMedium
+	ratio := float64(fn.ExternalFieldUses) / float64(max(fn.OwnFieldUses, 1))
+	if ratio > 3 {
+		severity = model.SeverityHigh
+	}
+
+	return model.DebtIssue{
+		Category:    model.CategoryCoupling,
+		Subcategory: "feature_envy",
+		Severity:    severity,
+		FilePath:    fn.FilePath,
+		StartLine:   fn.StartLine,
+		EndLine:     fn.EndLine,
+		EntityName:  fn.Name,
+		EntityType:  "function",
+		Description: fmt.Sprintf("Method uses %d external fields vs %d own fields", fn.ExternalFieldUses, fn.OwnFieldUses),
+		Metrics: map[string]any{
+			"external_field_uses": fn.ExternalFieldUses,
+			"own_field_uses":      fn.OwnFieldUses,
+			"ratio":               ratio,
+			"threshold":           cfg.FeatureEnvyThreshold,
+		},
+		Suggestion: "Consider moving this method to the class whose data it uses most",
+		Profile:    profile,
+	}
+}
+
+func (d *CouplingDetector) createHighCouplingIssue(cls model.ClassMetrics) model.DebtIssue {
+	cfg, profile := d.settings(cls.FilePath)
+	severity := model.SeverityMedium
+	if cls.DependencyCount > cfg.MaxDependencies*2 {
+		severity = model.SeverityHigh
+	}
+
+	return model.DebtIssue{
+		Category:    model.CategoryCoupling,
+		Subcategory: "high_coupling",
+		Severity:    severity,
+		FilePath:    cls.FilePath,
+		StartLine:   cls.StartLine,
+		EndLine:     cls.EndLine,
+		EntityName:  cls.Name,
+		EntityType:  "class",
+		Description: fmt.Sprintf("Class depends on %d other classes (threshold: %d)", cls.DependencyCount, cfg.MaxDependencies),
+		Metrics: map[string]any{
+			"dependency_count": cls.DependencyCount,
+			"threshold":        cfg.MaxDependencies,
+		},
+		Suggestion: "Reduce dependencies by introducing abstractions or reorganizing responsibilities",
+		Profile:    profile,
+	}
+}
+
+func (d *CouplingDetector) createIntimacyIssue(pair model.ClassPairMetrics) model.DebtIssue {
+	cfg, profile := d.settings(pair.Class1File)
+	severity := model.SeverityHigh // Bidirectional is always more serious
+
+	return model.DebtIssue{
+		Category:    model.CategoryCoupling,
+		Subcategory: "inappropriate_intimacy",
+		Severity:    severity,
+		FilePath:    pair.Class1File,
+		StartLine:   1,
+		EndLine:     1,
+		EntityName:  fmt.Sprintf("%s <-> %s", pair.Class1Name, pair.Class2Name),
+		EntityType:  "class_pair",
+		Description: fmt.Sprintf("Classes are too tightly coupled (%d calls each way)", min(pair.Calls1To2, pair.Calls2To1)),
+		Metrics: map[string]any{
+			"calls_1_to_2":        pair.Calls1To2,
+			"calls_2_to_1":        pair.Calls2To1,
+			"shared_field_access": pair.SharedFieldAccess,
+			"class1":              pair.Class1Name,
+			"class2":              pair.Class2Name,
+			"threshold":           cfg.IntimacyCallThreshold,
+		},
+		Suggestion: "Extract shared logic into a new class or merge if appropriate",
+		Profile:    profile,
+	}
+}
+
+func (d *CouplingDetector) createPrimitiveObsessionIssue(cls model.ClassMetrics) model.DebtIssue {
+	cfg, profile := d.settings(cls.FilePath)
+	return model.DebtIssue{
+		Category:    model.CategoryCoupling,
+		Subcategory: "primitive_obsession",
+		Severity:    model.SeverityMedium,
+		FilePath:    cls.FilePath,
+		StartLine:   cls.StartLine,
+		EndLine:     cls.EndLine,
+		EntityName:  cls.Name,
+		EntityType:  "class",
+		Description: fmt.Sprintf("Class has %d primitive fields (threshold: %d)", cls.PrimitiveFieldCount, cfg.PrimitiveFieldThreshold),
+		Metrics: map[string]any{
+			"primitive_field_count": cls.PrimitiveFieldCount,
+			"total_field_count":     cls.FieldCount,
+			"threshold":             cfg.PrimitiveFieldThreshold,
+		},
+		Suggestion: "Consider creating value objects or domain types for related primitives",
+		Profile:    profile,
+	}
+}
@@ -26,6 +26,7 @@ type BaseDetector struct {
 	Metrics    *metrics.Provider
 	Cfg        *config.Config
 	Exclusions *util.ExclusionMatcher
+	Profiles   *util.ProfileMatcher
 }
 
 // NewBaseDetector creates a new base detector
@@ -34,6 +35,7 @@ func NewBaseDetector(metricsProvider *metrics.Provider, cfg *config.Config) Base
 		Metrics:    metricsProvider,
 		Cfg:        cfg,
 		Exclusions: util.NewExclusionMatcher(cfg.Exclusions),
+		Profiles:   util.NewProfileMatcher(cfg),
 	}
 }
 
@@ -42,6 +44,24 @@ func (b *BaseDetector) ShouldExclude(filePath, className, funcName string) bool
 	return b.Exclusions.Matches(filePath, className, funcName)
 }
 
+// Settings returns the detector settings in effect for a file, after the
+// overrides of its language and path profile
+func (b *BaseDetector) Settings(filePath string) util.Settings {
+	return b.Profiles.Match(filePath)
+}
+
+// FilterDisabledRules drops issues whose rule is disabled for the language of
+// their file
+func (b *BaseDetector) FilterDisabledRules(issues []model.DebtIssue) []model.DebtIssue {
+	filtered := make([]model.DebtIssue, 0, len(issues))
+	for _, issue := range issues {
+		if !b.Settings(issue.FilePath).RuleDisabled(issue.RuleID()) {
+			filtered = append(filtered, issue)
+		}
+	}
+	return filtered
+}
+
 // FilterBySeverity filters issues by minimum severity
 func (b *BaseDetector) FilterBySeverity(issues []model.DebtIssue) []model.DebtIssue {
 	minSev := model.Severity(b.Cfg.Severity.MinSeverity)
@@ -4,11 +4,13 @@ import (
 	"context"
 	"fmt"
 	"sync"
+	"sync/atomic"
 
 	"quality-bot/src/config"
 	"quality-bot/src/model"
 	"quality-bot/src/service/codeapi"
 	"quality-bot/src/service/metrics"
+	"quality-bot/src/service/progress"
 	"quality-bot/src/util"
 )
 
@@ -64,14 +66,15 @@ func (d *DuplicationDetector) Detect(ctx context.Context) ([]model.DebtIssue, er
 			excluded++
 			continue
 		}
+		cfg, _ := d.settings(fn.FilePath)
 
-		if fn.LineCount < d.cfg.MinLines {
+		if fn.LineCount < cfg.MinLines {
 			tooSmall++
 			continue
 		}
 
 		// Skip trivial functions if configured
-		if d.cfg.SkipTrivial && d.isTrivialFunction(fn) {
+		if cfg.SkipTrivial && d.isTrivialFunction(fn) {
 			trivialSkipped++
 			continue
 		}
@@ -101,6 +104,17 @@ func (d *DuplicationDetector) Detect(ctx context.Context) ([]model.DebtIssue, er
 
 	util.Debug("Duplication detector: searching for similar code with %d workers", d.Cfg.Concurrency.SimilaritySearchWorkers)
 
+	var processed atomic.Int64
+	reportProgress := func(done int) {
+		progress.Emit(ctx, progress.Event{
+			Type:      progress.DuplicationProgress,
+			Detector:  d.Name(),
+			Completed: done,
+			Total:     len(candidates),
+		})
+	}
+	reportProgress(0)
+
 	for _, fn := range candidates {
 		wg.Add(1)
 		go func(fn model.FunctionMetrics) {
@@ -108,6 +122,7 @@ func (d *DuplicationDetector) Detect(ctx context.Context) ([]model.DebtIssue, er
 
 			sem <- struct{}{}
 			defer func() { <-sem }()
+			defer func() { reportProgress(int(processed.Add(1))) }()
 
 			matches, err := d.findSimilarFunctions(ctx, fn)
 			if err != nil {
@@ -135,7 +150,66 @@ func (d *DuplicationDetector) Detect(ctx context.Context) ([]model.DebtIssue, er
 	wg.Wait()
 
 	util.Debug("Duplication detector: found %d duplicate pairs", len(issues))
-	return d.FilterBySeverity(issues), nil
+	return d.FilterBySeverity(d.FilterDisabledRules(issues)), nil
+}
+
+// DetectFunction runs the similarity search for a single function, so its
+// duplicates can be inspected without searching the whole repository.
+// Functions the candidate filters skip have no duplicates.
+func (d *DuplicationDetector) DetectFunction(ctx context.Context, fn model.FunctionMetrics) ([]model.DebtIssue, error) {
+	if cfg, _ := d.settings(fn.FilePath); fn.LineCount < cfg.MinLines || (cfg.SkipTrivial && d.isTrivialFunction(fn)) {
+		return nil, nil
+	}
+
+	matches, err := d.findSimilarFunctions(ctx, fn)
+	if err != nil {
+		return nil, err
+	}
+
+	issues := make([]model.DebtIssue, 0, len(matches))
+	for _, match := range matches {
+		issues = append(issues, d.createDuplicationIssue(fn, match))
+	}
+	return d.FilterBySeverity(d.FilterDisabledRules(issues)), nil
+}
+
+// FunctionChecks reports whether a function is a candidate for the
+// similarity search. Duplicates themselves are found by DetectFunction.
+func (d *DuplicationDetector) FunctionChecks(fn model.FunctionMetrics) []model.ThresholdCheck {
+	cfg, _ := d.settings(fn.FilePath)
+	check := model.ThresholdCheck{
+		Detector:  d.Name(),
+		Rule:      "similar_code",
+		Metric:    "line_count",
+		Value:     float64(fn.LineCount),
+		Threshold: float64(cfg.MinLines),
+	}
+	switch {
+	case fn.LineCount < cfg.MinLines:
+		check.Note = "shorter than min_lines; not searched for duplicates"
+	case cfg.SkipTrivial && d.isTrivialFunction(fn):
+		check.Note = "trivial function; skipped (skip_trivial)"
+	default:
+		check.Note = fmt.Sprintf("searched for functions at least %.0f%% similar", cfg.SimilarityThreshold*100)
+	}
+	return []model.ThresholdCheck{check}
+}
+
+// ClassChecks returns nothing; duplication is detected per function
+func (d *DuplicationDetector) ClassChecks(model.ClassMetrics, []model.ClassPairMetrics) []model.ThresholdCheck {
+	return nil
+}
+
+// FileChecks returns nothing; duplication is detected per function
+func (d *DuplicationDetector) FileChecks(model.FileMetrics) []model.ThresholdCheck {
+	return nil
+}
+
+// settings returns the duplication settings in effect for a file and the
+// profile they come from. max_functions_to_check applies to the whole run.
+func (d *DuplicationDetector) settings(filePath string) (config.DuplicationDetectorConfig, string) {
+	settings := d.Settings(filePath)
+	return settings.Detectors.Duplication, settings.Profile
 }
 
 func (d *DuplicationDetector) isTrivialFunction(fn model.FunctionMetrics) bool {
@@ -174,7 +248,7 @@ func (d *DuplicationDetector) findSimilarFunctions(ctx context.Context, fn model
 	}
 
 	// Determine language from file extension
-	language := d.detectLanguage(fn.FilePath)
+	language := util.LanguageOf(fn.FilePath)
 	if language == "" {
 		return nil, nil // Unknown language
 	}
@@ -193,6 +267,7 @@ func (d *DuplicationDetector) findSimilarFunctions(ctx context.Context, fn model
 	}
 
 	// Filter out non-function matches and self-matches
+	cfg, _ := d.settings(fn.FilePath)
 	var matches []codeapi.SimilarCodeResult
 	for _, result := range resp.Results {
 		// Skip non-function matches (while, switch, for, etc.)
@@ -208,7 +283,7 @@ func (d *DuplicationDetector) findSimilarFunctions(ctx context.Context, fn model
 		}
 
 		// Only include matches above threshold
-		if result.Score >= d.cfg.SimilarityThreshold {
+		if result.Score >= cfg.SimilarityThreshold {
 			matches = append(matches, result)
 		}
 	}
@@ -301,35 +376,6 @@ func (d *DuplicationDetector) betterMatch(a, b codeapi.SimilarCodeResult) int {
 	return 1
 }
 
-func (d *DuplicationDetector) detectLanguage(filePath string) string {
-	ext := ""
-	if idx := len(filePath) - 1; idx >= 0 {
-		for i := len(filePath) - 1; i >= 0; i-- {
-			if filePath[i] == '.' {
-				ext = filePath[i+1:]
-				break
-			}
-		}
-	}
-
-	switch ext {
-	case "go":
-		return "go"
-	case "py":
-		return "python"
-	case "java":
-		return "java"
-	case "ts":
-		return "typescript"
-	case "js":
-		return "javascript"
-	case "cs":
-		return "csharp"
-	default:
-		return ""
-	}
-}
-
 func (d *DuplicationDetector) linesOverlap(start1, end1, start2, end2 int) bool {
 	return start1 <= end2 && start2 <= end1
 }
@@ -365,6 +411,7 @@ func (d *DuplicationDetector) pairKey(id1, id2 string) string {
 }
 
 func (d *DuplicationDetector) createDuplicationIssue(fn model.FunctionMetrics, match codeapi.SimilarCodeResult) model.DebtIssue {
+	_, profile := d.settings(fn.FilePath)
 	severity := model.SeverityMedium
 	if match.Score > 0.95 {
 		severity = model.SeverityHigh
@@ -388,5 +435,6 @@ func (d *DuplicationDetector) createDuplicationIssue(fn model.FunctionMetrics, m
 			"duplicate_line":     match.Chunk.StartLine,
 		},
 		Suggestion: "Extract common logic into a shared function",
+		Profile:    profile,
 	}
 }
@@ -0,0 +1,49 @@
+package detector
+
+import "quality-bot/src/model"
+
+// Explainer is implemented by detectors that can list the threshold checks
+// behind their decisions for a single entity. Checks do not consider
+// exclusions; an excluded entity is never checked.
+type Explainer interface {
+	Detector
+
+	// FunctionChecks returns the checks run on a function
+	FunctionChecks(fn model.FunctionMetrics) []model.ThresholdCheck
+
+	// ClassChecks returns the checks run on a class and on the class pairs
+	// it belongs to
+	ClassChecks(cls model.ClassMetrics, pairs []model.ClassPairMetrics) []model.ThresholdCheck
+
+	// FileChecks returns the checks run on a file
+	FileChecks(file model.FileMetrics) []model.ThresholdCheck
+}
+
+// newCheck compares value against threshold. issue builds the issue the
+// detector raises when the threshold is exceeded; its severity is recorded.
+func newCheck(detector, rule, metric string, value, threshold int, issue func() model.DebtIssue) model.ThresholdCheck {
+	check := model.ThresholdCheck{
+		Detector:  detector,
+		Rule:      rule,
+		Metric:    metric,
+		Value:     float64(value),
+		Threshold: float64(threshold),
+		Exceeded:  value > threshold,
+	}
+	if check.Exceeded {
+		check.Severity = issue().Severity
+	}
+	return check
+}
+
+// Explainers returns the registered detectors that implement Explainer,
+// enabled or not
+func (r *Runner) Explainers() []Explainer {
+	var explainers []Explainer
+	for _, d := range r.detectors {
+		if e, ok := d.(Explainer); ok {
+			explainers = append(explainers, e)
+		}
+	}
+	return explainers
+}
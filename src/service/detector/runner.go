@@ -3,6 +3,7 @@ package detector
 import (
 	"context"
 	"fmt"
+	"strings"
 	"sync"
 	"time"
 
@@ -10,6 +11,8 @@ import (
 	"quality-bot/src/model"
 	"quality-bot/src/service/codeapi"
 	"quality-bot/src/service/metrics"
+	"quality-bot/src/service/progress"
+	"quality-bot/src/service/telemetry"
 	"quality-bot/src/util"
 )
 
@@ -18,6 +21,7 @@ import (
 type Runner struct {
 	detectors []Detector
 	cfg       *config.Config
+	base      BaseDetector
 }
 
 // NewRunner creates a new detector runner with all detectors registered
@@ -44,29 +48,39 @@ func NewRunner(metricsProvider *metrics.Provider, codeapiClient *codeapi.Client,
 	return &Runner{
 		detectors: detectors,
 		cfg:       cfg,
+		base:      base,
 	}
 }
 
 // RunAll executes all enabled detectors and returns combined issues
 func (r *Runner) RunAll(ctx context.Context) ([]model.DebtIssue, error) {
+	return r.Run(ctx, nil)
+}
+
+// Run executes the named detectors, or all enabled detectors if names is
+// empty, and returns combined issues. Named detectors run even if they are
+// disabled in the configuration.
+func (r *Runner) Run(ctx context.Context, names []string) ([]model.DebtIssue, error) {
+	selected, err := r.selectDetectors(names)
+	if err != nil {
+		return nil, err
+	}
+
+	r.ResolveLanguages(ctx)
+
 	startTime := time.Now()
 	util.Info("Starting debt detection")
+	progress.Emit(ctx, progress.Event{Type: progress.DetectionStarted, Total: len(selected)})
 
 	var (
 		allIssues []model.DebtIssue
 		mu        sync.Mutex
 		wg        sync.WaitGroup
-		errChan   = make(chan error, len(r.detectors))
+		errChan   = make(chan error, len(selected))
 		sem       = make(chan struct{}, r.cfg.Concurrency.MaxParallelDetectors)
 	)
 
-	enabledCount := 0
-	for _, d := range r.detectors {
-		if !d.IsEnabled() {
-			util.Debug("Skipping disabled detector: %s", d.Name())
-			continue
-		}
-		enabledCount++
+	for _, d := range selected {
 
 		wg.Add(1)
 		go func(detector Detector) {
@@ -77,8 +91,20 @@ func (r *Runner) RunAll(ctx context.Context) ([]model.DebtIssue, error) {
 
 			detectorStart := time.Now()
 			util.Debug("Running detector: %s", detector.Name())
+			progress.Emit(ctx, progress.Event{Type: progress.DetectorStarted, Detector: detector.Name()})
 
 			issues, err := detector.Detect(ctx)
+			telemetry.ObserveDetector(detector.Name(), time.Since(detectorStart), err)
+			finished := progress.Event{
+				Type:       progress.DetectorFinished,
+				Detector:   detector.Name(),
+				Issues:     len(issues),
+				DurationMs: time.Since(detectorStart).Milliseconds(),
+			}
+			if err != nil {
+				finished.Error = err.Error()
+			}
+			progress.Emit(ctx, finished)
 			if err != nil {
 				util.Error("Detector %s failed: %v", detector.Name(), err)
 				if r.cfg.Detectors.FailFast {
@@ -95,7 +121,7 @@ func (r *Runner) RunAll(ctx context.Context) ([]model.DebtIssue, error) {
 		}(d)
 	}
 
-	util.Debug("Running %d enabled detectors (max parallel: %d)", enabledCount, r.cfg.Concurrency.MaxParallelDetectors)
+	util.Debug("Running %d detectors (max parallel: %d)", len(selected), r.cfg.Concurrency.MaxParallelDetectors)
 
 	wg.Wait()
 	close(errChan)
@@ -110,6 +136,60 @@ func (r *Runner) RunAll(ctx context.Context) ([]model.DebtIssue, error) {
 	return allIssues, nil
 }
 
+// ResolveLanguages tells detectors the language CodeAPI reports for each
+// file, when per-language settings are configured. Without it, or if file
+// metrics cannot be fetched, languages follow file extensions.
+func (r *Runner) ResolveLanguages(ctx context.Context) {
+	if len(r.cfg.Languages) == 0 {
+		return
+	}
+
+	files, err := r.base.Metrics.GetAllFileMetrics(ctx)
+	if err != nil {
+		util.Warn("Resolving file languages by extension: %v", err)
+		return
+	}
+
+	languages := make(map[string]string, len(files))
+	for _, file := range files {
+		if file.Language != "" {
+			languages[file.Path] = file.Language
+		}
+	}
+	r.base.Profiles.SetFileLanguages(languages)
+	util.Debug("Resolved languages of %d files", len(languages))
+}
+
+// Settings returns the detector settings in effect for a file
+func (r *Runner) Settings(filePath string) util.Settings {
+	return r.base.Settings(filePath)
+}
+
+// selectDetectors resolves detector names; an empty list selects every
+// enabled detector
+func (r *Runner) selectDetectors(names []string) ([]Detector, error) {
+	var selected []Detector
+	if len(names) == 0 {
+		for _, d := range r.detectors {
+			if !d.IsEnabled() {
+				util.Debug("Skipping disabled detector: %s", d.Name())
+				continue
+			}
+			selected = append(selected, d)
+		}
+		return selected, nil
+	}
+
+	for _, name := range names {
+		d := r.GetDetector(name)
+		if d == nil {
+			return nil, fmt.Errorf("unknown detector %q (available: %s)", name, strings.Join(r.ListDetectors(), ", "))
+		}
+		selected = append(selected, d)
+	}
+	return selected, nil
+}
+
 // GetDetector returns a detector by name
 func (r *Runner) GetDetector(name string) Detector {
 	for _, d := range r.detectors {
@@ -62,7 +62,7 @@ func (d *SizeAndStructureDetector) Detect(ctx context.Context) ([]model.DebtIssu
 	issues = append(issues, fileIssues...)
 	util.Debug("Size detector: found %d file-level issues", len(fileIssues))
 
-	return d.FilterBySeverity(issues), nil
+	return d.FilterBySeverity(d.FilterDisabledRules(issues)), nil
 }
 
 func (d *SizeAndStructureDetector) detectFunctionIssues(ctx context.Context) ([]model.DebtIssue, error) {
@@ -77,14 +77,15 @@ func (d *SizeAndStructureDetector) detectFunctionIssues(ctx context.Context) ([]
 		if d.ShouldExclude(fn.FilePath, fn.ClassName, fn.Name) {
 			continue
 		}
+		cfg, _ := d.settings(fn.FilePath)
 
 		// Check function length
-		if fn.LineCount > d.cfg.MaxFunctionLines {
+		if fn.LineCount > cfg.MaxFunctionLines {
 			issues = append(issues, d.createLongMethodIssue(fn))
 		}
 
 		// Check parameter count
-		if fn.ParameterCount > d.cfg.MaxParameters {
+		if fn.ParameterCount > cfg.MaxParameters {
 			issues = append(issues, d.createLongParameterListIssue(fn))
 		}
 	}
@@ -104,14 +105,15 @@ func (d *SizeAndStructureDetector) detectClassIssues(ctx context.Context) ([]mod
 		if d.ShouldExclude(cls.FilePath, cls.Name, "") {
 			continue
 		}
+		cfg, _ := d.settings(cls.FilePath)
 
 		// Check method count (god class)
-		if cls.MethodCount > d.cfg.MaxClassMethods {
+		if cls.MethodCount > cfg.MaxClassMethods {
 			issues = append(issues, d.createGodClassMethodIssue(cls))
 		}
 
 		// Check field count
-		if cls.FieldCount > d.cfg.MaxClassFields {
+		if cls.FieldCount > cfg.MaxClassFields {
 			issues = append(issues, d.createGodClassFieldIssue(cls))
 		}
 	}
@@ -131,14 +133,15 @@ func (d *SizeAndStructureDetector) detectFileIssues(ctx context.Context) ([]mode
 		if d.ShouldExclude(file.Path, "", "") {
 			continue
 		}
+		cfg, _ := d.settings(file.Path)
 
 		// Check file line count
-		if file.LineCount > d.cfg.MaxFileLines {
+		if file.LineCount > cfg.MaxFileLines {
 			issues = append(issues, d.createLargeFileLineIssue(file))
 		}
 
 		// Check function count
-		if file.FunctionCount > d.cfg.MaxFileFunctions {
+		if file.FunctionCount > cfg.MaxFileFunctions {
 			issues = append(issues, d.createLargeFileFunctionIssue(file))
 		}
 	}
@@ -146,9 +149,50 @@ func (d *SizeAndStructureDetector) detectFileIssues(ctx context.Context) ([]mode
 	return issues, nil
 }
 
+// settings returns the size thresholds in effect for a file and the profile
+// they come from
+func (d *SizeAndStructureDetector) settings(filePath string) (config.SizeDetectorConfig, string) {
+	settings := d.Settings(filePath)
+	return settings.Detectors.SizeAndStructure, settings.Profile
+}
+
+// FunctionChecks returns the size checks run on a function
+func (d *SizeAndStructureDetector) FunctionChecks(fn model.FunctionMetrics) []model.ThresholdCheck {
+	cfg, _ := d.settings(fn.FilePath)
+	return []model.ThresholdCheck{
+		newCheck(d.Name(), "long_method", "line_count", fn.LineCount, cfg.MaxFunctionLines,
+			func() model.DebtIssue { return d.createLongMethodIssue(fn) }),
+		newCheck(d.Name(), "long_parameter_list", "parameter_count", fn.ParameterCount, cfg.MaxParameters,
+			func() model.DebtIssue { return d.createLongParameterListIssue(fn) }),
+	}
+}
+
+// ClassChecks returns the size checks run on a class
+func (d *SizeAndStructureDetector) ClassChecks(cls model.ClassMetrics, _ []model.ClassPairMetrics) []model.ThresholdCheck {
+	cfg, _ := d.settings(cls.FilePath)
+	return []model.ThresholdCheck{
+		newCheck(d.Name(), "god_class", "method_count", cls.MethodCount, cfg.MaxClassMethods,
+			func() model.DebtIssue { return d.createGodClassMethodIssue(cls) }),
+		newCheck(d.Name(), "god_class", "field_count", cls.FieldCount, cfg.MaxClassFields,
+			func() model.DebtIssue { return d.createGodClassFieldIssue(cls) }),
+	}
+}
+
+// FileChecks returns the size checks run on a file
+func (d *SizeAndStructureDetector) FileChecks(file model.FileMetrics) []model.ThresholdCheck {
+	cfg, _ := d.settings(file.Path)
+	return []model.ThresholdCheck{
+		newCheck(d.Name(), "large_file", "line_count", file.LineCount, cfg.MaxFileLines,
+			func() model.DebtIssue { return d.createLargeFileLineIssue(file) }),
+		newCheck(d.Name(), "large_file", "function_count", file.FunctionCount, cfg.MaxFileFunctions,
+			func() model.DebtIssue { return d.createLargeFileFunctionIssue(file) }),
+	}
+}
+
 func (d *SizeAndStructureDetector) createLongMethodIssue(fn model.FunctionMetrics) model.DebtIssue {
+	cfg, profile := d.settings(fn.FilePath)
 	severity := model.SeverityMedium
-	if fn.LineCount > d.cfg.MaxFunctionLines*2 {
+	if fn.LineCount > cfg.MaxFunctionLines*2 {
 		severity = model.SeverityHigh
 	}
 
@@ -161,18 +205,20 @@ func (d *SizeAndStructureDetector) createLongMethodIssue(fn model.FunctionMetric
 		EndLine:     fn.EndLine,
 		EntityName:  fn.Name,
 		EntityType:  "function",
-		Description: fmt.Sprintf("Method is too long (%d lines, threshold: %d)", fn.LineCount, d.cfg.MaxFunctionLines),
+		Description: fmt.Sprintf("Method is too long (%d lines, threshold: %d)", fn.LineCount, cfg.MaxFunctionLines),
 		Metrics: map[string]any{
 			"line_count": fn.LineCount,
-			"threshold":  d.cfg.MaxFunctionLines,
+			"threshold":  cfg.MaxFunctionLines,
 		},
 		Suggestion: "Extract smaller, single-purpose methods",
+		Profile:    profile,
 	}
 }
 
 func (d *SizeAndStructureDetector) createLongParameterListIssue(fn model.FunctionMetrics) model.DebtIssue {
+	cfg, profile := d.settings(fn.FilePath)
 	severity := model.SeverityMedium
-	if fn.ParameterCount > d.cfg.MaxParameters*2 {
+	if fn.ParameterCount > cfg.MaxParameters*2 {
 		severity = model.SeverityHigh
 	}
 
@@ -185,18 +231,20 @@ func (d *SizeAndStructureDetector) createLongParameterListIssue(fn model.Functio
 		EndLine:     fn.EndLine,
 		EntityName:  fn.Name,
 		EntityType:  "function",
-		Description: fmt.Sprintf("Too many parameters (%d, threshold: %d)", fn.ParameterCount, d.cfg.MaxParameters),
+		Description: fmt.Sprintf("Too many parameters (%d, threshold: %d)", fn.ParameterCount, cfg.MaxParameters),
 		Metrics: map[string]any{
 			"parameter_count": fn.ParameterCount,
-			"threshold":       d.cfg.MaxParameters,
+			"threshold":       cfg.MaxParameters,
 		},
 		Suggestion: "Consider using a parameter object or builder pattern",
+		Profile:    profile,
 	}
 }
 
 func (d *SizeAndStructureDetector) createGodClassMethodIssue(cls model.ClassMetrics) model.DebtIssue {
+	cfg, profile := d.settings(cls.FilePath)
 	severity := model.SeverityMedium
-	if cls.MethodCount > d.cfg.MaxClassMethods*2 {
+	if cls.MethodCount > cfg.MaxClassMethods*2 {
 		severity = model.SeverityHigh
 	}
 
@@ -209,18 +257,20 @@ func (d *SizeAndStructureDetector) createGodClassMethodIssue(cls model.ClassMetr
 		EndLine:     cls.EndLine,
 		EntityName:  cls.Name,
 		EntityType:  "class",
-		Description: fmt.Sprintf("Class has too many methods (%d, threshold: %d)", cls.MethodCount, d.cfg.MaxClassMethods),
+		Description: fmt.Sprintf("Class has too many methods (%d, threshold: %d)", cls.MethodCount, cfg.MaxClassMethods),
 		Metrics: map[string]any{
 			"method_count": cls.MethodCount,
-			"threshold":    d.cfg.MaxClassMethods,
+			"threshold":    cfg.MaxClassMethods,
 		},
 		Suggestion: "Split into smaller, focused classes following Single Responsibility Principle",
+		Profile:    profile,
 	}
 }
 
 func (d *SizeAndStructureDetector) createGodClassFieldIssue(cls model.ClassMetrics) model.DebtIssue {
+	cfg, profile := d.settings(cls.FilePath)
 	severity := model.SeverityMedium
-	if cls.FieldCount > d.cfg.MaxClassFields*2 {
+	if cls.FieldCount > cfg.MaxClassFields*2 {
 		severity = model.SeverityHigh
 	}
 
@@ -233,18 +283,20 @@ func (d *SizeAndStructureDetector) createGodClassFieldIssue(cls model.ClassMetri
 		EndLine:     cls.EndLine,
 		EntityName:  cls.Name,
 		EntityType:  "class",
-		Description: fmt.Sprintf("Class has too many fields (%d, threshold: %d)", cls.FieldCount, d.cfg.MaxClassFields),
+		Description: fmt.Sprintf("Class has too many fields (%d, threshold: %d)", cls.FieldCount, cfg.MaxClassFields),
 		Metrics: map[string]any{
 			"field_count": cls.FieldCount,
-			"threshold":   d.cfg.MaxClassFields,
+			"threshold":   cfg.MaxClassFields,
 		},
 		Suggestion: "Consider breaking into smaller classes or extracting value objects",
+		Profile:    profile,
 	}
 }
 
 func (d *SizeAndStructureDetector) createLargeFileLineIssue(file model.FileMetrics) model.DebtIssue {
+	cfg, profile := d.settings(file.Path)
 	severity := model.SeverityMedium
-	if file.LineCount > d.cfg.MaxFileLines*2 {
+	if file.LineCount > cfg.MaxFileLines*2 {
 		severity = model.SeverityHigh
 	}
 
@@ -257,18 +309,20 @@ func (d *SizeAndStructureDetector) createLargeFileLineIssue(file model.FileMetri
 		EndLine:     file.LineCount,
 		EntityName:  file.Path,
 		EntityType:  "file",
-		Description: fmt.Sprintf("File is too large (%d lines, threshold: %d)", file.LineCount, d.cfg.MaxFileLines),
+		Description: fmt.Sprintf("File is too large (%d lines, threshold: %d)", file.LineCount, cfg.MaxFileLines),
 		Metrics: map[string]any{
 			"line_count": file.LineCount,
-			"threshold":  d.cfg.MaxFileLines,
+			"threshold":  cfg.MaxFileLines,
 		},
 		Suggestion: "Split into multiple files organized by responsibility",
+		Profile:    profile,
 	}
 }
 
 func (d *SizeAndStructureDetector) createLargeFileFunctionIssue(file model.FileMetrics) model.DebtIssue {
+	cfg, profile := d.settings(file.Path)
 	severity := model.SeverityMedium
-	if file.FunctionCount > d.cfg.MaxFileFunctions*2 {
+	if file.FunctionCount > cfg.MaxFileFunctions*2 {
 		severity = model.SeverityHigh
 	}
 
@@ -281,11 +335,12 @@ func (d *SizeAndStructureDetector) createLargeFileFunctionIssue(file model.FileM
 		EndLine:     file.LineCount,
 		EntityName:  file.Path,
 		EntityType:  "file",
-		Description: fmt.Sprintf("File has too many functions (%d, threshold: %d)", file.FunctionCount, d.cfg.MaxFileFunctions),
+		Description: fmt.Sprintf("File has too many functions (%d, threshold: %d)", file.FunctionCount, cfg.MaxFileFunctions),
 		Metrics: map[string]any{
 			"function_count": file.FunctionCount,
-			"threshold":      d.cfg.MaxFileFunctions,
+			"threshold":      cfg.MaxFileFunctions,
 		},
 		Suggestion: "Split into multiple files organized by feature or domain",
+		Profile:    profile,
 	}
 }
@@ -0,0 +1,213 @@
+package diffscope
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"quality-bot/src/model"
+	"quality-bot/src/service/vcs"
+	"quality-bot/src/util"
+)
+
+// LineRange is an inclusive range of changed lines in the new file version
+type LineRange struct {
+	Start int
+	End   int
+}
+
+// ChangeSet records which lines of which files were changed. A file present
+// without ranges is treated as changed in full.
+type ChangeSet struct {
+	files map[string][]LineRange
+}
+
+// NewChangeSet creates an empty change set
+func NewChangeSet() *ChangeSet {
+	return &ChangeSet{files: make(map[string][]LineRange)}
+}
+
+// hunkHeader matches the new-file side of a unified diff hunk header,
+// e.g. "@@ -10,2 +12,3 @@"
+var hunkHeader = regexp.MustCompile(`^@@ -\d+(?:,\d+)? \+(\d+)(?:,(\d+))? @@`)
+
+// FromGit builds a change set from the differences between the merge base of
+// ref and HEAD and the working tree of the checkout at dir. Paths are relative
+// to dir, like those CodeAPI reports.
+func FromGit(ctx context.Context, dir, ref string) (*ChangeSet, error) {
+	base, err := vcs.Git(ctx, dir, "merge-base", ref, "HEAD")
+	if err != nil {
+		return nil, fmt.Errorf("resolving merge base of %s: %w", ref, err)
+	}
+	base = strings.TrimSpace(base)
+
+	diff, err := vcs.Git(ctx, dir, "diff", "--unified=0", "--no-color", "--no-ext-diff", "--relative",
+		"--src-prefix=a/", "--dst-prefix=b/", base, "--")
+	if err != nil {
+		return nil, fmt.Errorf("running git diff: %w", err)
+	}
+
+	cs := ParseUnifiedDiff(diff)
+	util.Debug("Change set from git (%s..working tree): %d files", base, cs.FileCount())
+	return cs, nil
+}
+
+// ParseUnifiedDiff builds a change set from unified diff text. Pure deletions
+// mark the line where content was removed.
+func ParseUnifiedDiff(diff string) *ChangeSet {
+	cs := NewChangeSet()
+	current := ""
+
+	scanner := bufio.NewScanner(strings.NewReader(diff))
+	scanner.Buffer(make([]byte, 0, 64*1024), 4*1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		switch {
+		case strings.HasPrefix(line, "+++ "):
+			path := strings.TrimRight(strings.TrimPrefix(line, "+++ "), "\t")
+			if path == "/dev/null" {
+				current = "" // File deleted, nothing left to report on
+				continue
+			}
+			current = normalizePath(strings.TrimPrefix(path, "b/"))
+			if _, ok := cs.files[current]; !ok {
+				cs.files[current] = []LineRange{}
+			}
+
+		case strings.HasPrefix(line, "@@") && current != "":
+			m := hunkHeader.FindStringSubmatch(line)
+			if m == nil {
+				continue
+			}
+			start, _ := strconv.Atoi(m[1])
+			count := 1
+			if m[2] != "" {
+				count, _ = strconv.Atoi(m[2])
+			}
+
+			r := LineRange{Start: start, End: start + count - 1}
+			if count == 0 {
+				r = LineRange{Start: max(start, 1), End: max(start, 1)}
+			}
+			cs.files[current] = append(cs.files[current], r)
+		}
+	}
+
+	return cs
+}
+
+// FromFile builds a change set from a list of changed files, one per line.
+// Entries may carry a line range ("path:10-20" or "path:15"); plain paths
+// mark the whole file as changed. Blank lines and "#" comments are ignored.
+func FromFile(listPath string) (*ChangeSet, error) {
+	data, err := os.ReadFile(listPath)
+	if err != nil {
+		return nil, fmt.Errorf("reading changed files list: %w", err)
+	}
+
+	cs := NewChangeSet()
+	whole := make(map[string]bool)
+
+	for n, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		path, r, err := parseEntry(line)
+		if err != nil {
+			return nil, fmt.Errorf("%s:%d: %w", listPath, n+1, err)
+		}
+
+		if r == nil {
+			whole[path] = true
+			cs.files[path] = []LineRange{}
+			continue
+		}
+		if !whole[path] {
+			cs.files[path] = append(cs.files[path], *r)
+		}
+	}
+
+	util.Debug("Change set from %s: %d files", listPath, cs.FileCount())
+	return cs, nil
+}
+
+func parseEntry(entry string) (string, *LineRange, error) {
+	idx := strings.LastIndex(entry, ":")
+	if idx < 0 {
+		return normalizePath(entry), nil, nil
+	}
+
+	spec := entry[idx+1:]
+	startStr, endStr, isRange := strings.Cut(spec, "-")
+	start, err := strconv.Atoi(startStr)
+	if err != nil {
+		// Not a line spec, the colon is part of the path
+		return normalizePath(entry), nil, nil
+	}
+
+	end := start
+	if isRange {
+		if end, err = strconv.Atoi(endStr); err != nil || end < start {
+			return "", nil, fmt.Errorf("invalid line range %q", spec)
+		}
+	}
+
+	return normalizePath(entry[:idx]), &LineRange{Start: start, End: end}, nil
+}
+
+// FileCount returns the number of changed files
+func (c *ChangeSet) FileCount() int {
+	return len(c.files)
+}
+
+// Filter returns only the issues touched by the change set
+func (c *ChangeSet) Filter(issues []model.DebtIssue) []model.DebtIssue {
+	filtered := make([]model.DebtIssue, 0, len(issues))
+	for _, issue := range issues {
+		if c.Touches(issue) {
+			filtered = append(filtered, issue)
+		}
+	}
+	return filtered
+}
+
+// Touches reports whether an issue's entity intersects a changed hunk.
+// File-level issues (and class pairs, which have no single line range)
+// match any change in their file.
+func (c *ChangeSet) Touches(issue model.DebtIssue) bool {
+	ranges, ok := c.lookup(issue.FilePath)
+	if !ok {
+		return false
+	}
+
+	if len(ranges) == 0 || issue.EntityType == "file" || issue.EntityType == "class_pair" {
+		return true
+	}
+
+	end := max(issue.EndLine, issue.StartLine)
+	for _, r := range ranges {
+		if issue.StartLine <= r.End && r.Start <= end {
+			return true
+		}
+	}
+	return false
+}
+
+// lookup finds the ranges for a repo-relative path, as both git and CodeAPI
+// report them. Only exact matches count: "a.go" must not match "pkg/a.go".
+func (c *ChangeSet) lookup(filePath string) ([]LineRange, bool) {
+	ranges, ok := c.files[normalizePath(filePath)]
+	return ranges, ok
+}
+
+func normalizePath(path string) string {
+	path = strings.TrimPrefix(path, "./")
+	return strings.TrimPrefix(path, "/")
+}
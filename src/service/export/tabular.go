@@ -0,0 +1,126 @@
+package export
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Table is a flat set of rows with an ordered header, the common shape of
+// CSV and JSON Lines exports
+type Table struct {
+	Header []string
+	Rows   []map[string]any
+}
+
+// FromStructs builds a table from a slice of structs, using their JSON
+// field names as columns in declaration order
+func FromStructs[T any](items []T) Table {
+	var t Table
+	typ := reflect.TypeOf((*T)(nil)).Elem()
+
+	var fields []int
+	for i := 0; i < typ.NumField(); i++ {
+		name := jsonName(typ.Field(i))
+		if name == "" {
+			continue
+		}
+		fields = append(fields, i)
+		t.Header = append(t.Header, name)
+	}
+
+	for _, item := range items {
+		v := reflect.ValueOf(item)
+		row := make(map[string]any, len(fields))
+		for n, i := range fields {
+			row[t.Header[n]] = v.Field(i).Interface()
+		}
+		t.Rows = append(t.Rows, row)
+	}
+
+	return t
+}
+
+// CSV encodes the table with a header row. Missing values are empty cells.
+func (t Table) CSV() (string, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+
+	if err := w.Write(t.Header); err != nil {
+		return "", err
+	}
+	for _, row := range t.Rows {
+		record := make([]string, len(t.Header))
+		for i, col := range t.Header {
+			record[i] = formatValue(row[col])
+		}
+		if err := w.Write(record); err != nil {
+			return "", err
+		}
+	}
+
+	w.Flush()
+	return buf.String(), w.Error()
+}
+
+// JSONL encodes one JSON object per row. Keys missing from a row are omitted.
+func (t Table) JSONL() (string, error) {
+	var sb strings.Builder
+	for _, row := range t.Rows {
+		data, err := json.Marshal(row)
+		if err != nil {
+			return "", err
+		}
+		sb.Write(data)
+		sb.WriteByte('\n')
+	}
+	return sb.String(), nil
+}
+
+// Encode renders the table as "csv" or "jsonl"
+func (t Table) Encode(format string) (string, error) {
+	switch format {
+	case "csv":
+		return t.CSV()
+	case "jsonl":
+		return t.JSONL()
+	default:
+		return "", fmt.Errorf("unsupported export format: %s", format)
+	}
+}
+
+func formatValue(v any) string {
+	switch val := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return val
+	case float64:
+		return strconv.FormatFloat(val, 'f', -1, 64)
+	case float32:
+		return strconv.FormatFloat(float64(val), 'f', -1, 32)
+	case []string:
+		return strings.Join(val, ";")
+	default:
+		return fmt.Sprint(val)
+	}
+}
+
+func jsonName(f reflect.StructField) string {
+	if !f.IsExported() {
+		return ""
+	}
+	tag := f.Tag.Get("json")
+	if tag == "-" {
+		return ""
+	}
+	name, _, _ := strings.Cut(tag, ",")
+	if name == "" {
+		return f.Name
+	}
+	return name
+}
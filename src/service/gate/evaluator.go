@@ -0,0 +1,107 @@
+package gate
+
+import (
+	"fmt"
+	"sort"
+
+	"quality-bot/src/config"
+	"quality-bot/src/model"
+	"quality-bot/src/util"
+)
+
+// Evaluator checks an analysis report against the quality gate conditions
+type Evaluator struct {
+	cfg config.QualityGateConfig
+}
+
+// NewEvaluator creates a new quality gate evaluator
+func NewEvaluator(cfg config.QualityGateConfig) *Evaluator {
+	return &Evaluator{cfg: cfg}
+}
+
+// Evaluate returns the gate verdict for the report. A gate without any
+// configured condition always passes.
+func (e *Evaluator) Evaluate(report *model.AnalysisReport) *model.GateResult {
+	var conditions []model.GateCondition
+
+	for _, sev := range sortedKeys(e.cfg.MaxIssuesBySeverity) {
+		limit := e.cfg.MaxIssuesBySeverity[sev]
+		actual := report.Summary.BySeverity[model.Severity(sev)]
+		conditions = append(conditions, newCondition(fmt.Sprintf("%s issues", sev), limit, actual))
+	}
+
+	for _, cat := range sortedKeys(e.cfg.MaxIssuesByCategory) {
+		limit := e.cfg.MaxIssuesByCategory[cat]
+		actual := report.Summary.ByCategory[model.Category(cat)]
+		conditions = append(conditions, newCondition(fmt.Sprintf("%s issues", cat), limit, actual))
+	}
+
+	if e.cfg.MaxDebtScore > 0 {
+		conditions = append(conditions, model.GateCondition{
+			Name:      "debt score",
+			Threshold: e.cfg.MaxDebtScore,
+			Actual:    report.Summary.DebtScore,
+			Passed:    report.Summary.DebtScore <= e.cfg.MaxDebtScore,
+		})
+	}
+
+	if e.cfg.FailOnNewCritical {
+		if report.Baseline == nil {
+			util.Warn("Quality gate: fail_on_new_critical is set but no baseline report was provided")
+		} else {
+			conditions = append(conditions, newCondition("new critical issues", 0, countNewCritical(report)))
+		}
+	}
+
+	result := &model.GateResult{Passed: true, Conditions: conditions}
+	for _, c := range conditions {
+		if !c.Passed {
+			result.Passed = false
+		}
+	}
+
+	util.Debug("Quality gate evaluated %d conditions (passed: %v)", len(conditions), result.Passed)
+	return result
+}
+
+// countNewCritical counts critical issues that are absent from the baseline
+// or were less severe there
+func countNewCritical(report *model.AnalysisReport) int {
+	newIssues := make(map[string]int)
+	for _, fp := range report.Baseline.NewIssues {
+		newIssues[fp]++
+	}
+	for _, change := range report.Baseline.Escalated {
+		if change.To == model.SeverityCritical {
+			newIssues[change.Fingerprint]++
+		}
+	}
+
+	count := 0
+	for _, issue := range report.Issues {
+		fp := issue.Fingerprint()
+		if issue.Severity == model.SeverityCritical && newIssues[fp] > 0 {
+			newIssues[fp]--
+			count++
+		}
+	}
+	return count
+}
+
+func newCondition(name string, limit, actual int) model.GateCondition {
+	return model.GateCondition{
+		Name:      name,
+		Threshold: float64(limit),
+		Actual:    float64(actual),
+		Passed:    actual <= limit,
+	}
+}
+
+func sortedKeys(m map[string]int) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,140 @@
+package history
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"quality-bot/src/model"
+	"quality-bot/src/util"
+)
+
+// Store keeps analysis snapshots as JSON Lines, one file per repository
+type Store struct {
+	dir string
+}
+
+// NewStore creates a history store rooted at dir
+func NewStore(dir string) *Store {
+	return &Store{dir: dir}
+}
+
+// NewSnapshot builds the history record for a report
+func NewSnapshot(report *model.AnalysisReport, commit string) model.Snapshot {
+	records := make([]model.IssueRecord, 0, len(report.Issues))
+	for _, issue := range report.Issues {
+		records = append(records, model.IssueRecord{
+			Fingerprint: issue.Fingerprint(),
+			Rule:        issue.RuleID(),
+			Severity:    issue.Severity,
+			FilePath:    issue.FilePath,
+		})
+	}
+
+	// Per-file and per-directory breakdowns are not needed for trends and
+	// would grow the history file with every run
+	summary := report.Summary
+	summary.FileScores = nil
+	summary.DirectoryScores = nil
+	summary.Directories = nil
+
+	return model.Snapshot{
+		RepoName:    report.RepoName,
+		Commit:      commit,
+		GeneratedAt: report.GeneratedAt,
+		Summary:     summary,
+		Issues:      records,
+	}
+}
+
+// Save appends a snapshot to the repository's history file
+func (s *Store) Save(snapshot model.Snapshot) error {
+	if err := os.MkdirAll(s.dir, 0755); err != nil {
+		return fmt.Errorf("creating history directory: %w", err)
+	}
+
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		return fmt.Errorf("encoding snapshot: %w", err)
+	}
+
+	path := s.path(snapshot.RepoName)
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("opening history file: %w", err)
+	}
+	defer f.Close()
+
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("writing snapshot: %w", err)
+	}
+
+	util.Debug("Saved history snapshot for %s (commit: %s) to %s", snapshot.RepoName, snapshot.Commit, path)
+	return nil
+}
+
+// Load returns all snapshots of a repository, oldest first. A repository
+// without history yields no snapshots and no error.
+func (s *Store) Load(repoName string) ([]model.Snapshot, error) {
+	f, err := os.Open(s.path(repoName))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("opening history file: %w", err)
+	}
+	defer f.Close()
+
+	var snapshots []model.Snapshot
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 1024*1024), 64*1024*1024)
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum++
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var snapshot model.Snapshot
+		if err := json.Unmarshal(scanner.Bytes(), &snapshot); err != nil {
+			util.Warn("Skipping corrupt history line %d for %s: %v", lineNum, repoName, err)
+			continue
+		}
+		snapshots = append(snapshots, snapshot)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading history file: %w", err)
+	}
+
+	sort.SliceStable(snapshots, func(i, j int) bool {
+		return snapshots[i].GeneratedAt.Before(snapshots[j].GeneratedAt)
+	})
+
+	util.Debug("Loaded %d history snapshots for %s", len(snapshots), repoName)
+	return snapshots, nil
+}
+
+func (s *Store) path(repoName string) string {
+	return filepath.Join(s.dir, fileName(repoName)+".jsonl")
+}
+
+// fileName percent-encodes every byte of a repository name outside
+// [A-Za-z0-9._-], so distinct names such as "org/a" and "org_a" never share
+// a history file
+func fileName(repoName string) string {
+	var b strings.Builder
+	for i := 0; i < len(repoName); i++ {
+		c := repoName[i]
+		switch {
+		case 'a' <= c && c <= 'z', 'A' <= c && c <= 'Z', '0' <= c && c <= '9', c == '.', c == '_', c == '-':
+			b.WriteByte(c)
+		default:
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+	return b.String()
+}
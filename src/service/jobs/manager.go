@@ -0,0 +1,391 @@
+package jobs
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"quality-bot/src/config"
+	"quality-bot/src/model"
+	"quality-bot/src/util"
+)
+
+// Status is the lifecycle state of a job
+type Status string
+
+const (
+	StatusQueued    Status = "queued"
+	StatusRunning   Status = "running"
+	StatusSucceeded Status = "succeeded"
+	StatusFailed    Status = "failed"
+	StatusCanceled  Status = "canceled"
+)
+
+// Finished reports whether the job has reached a terminal state
+func (s Status) Finished() bool {
+	return s == StatusSucceeded || s == StatusFailed || s == StatusCanceled
+}
+
+var (
+	ErrNotFound    = errors.New("job not found")
+	ErrNotFinished = errors.New("job has not finished")
+	ErrFinished    = errors.New("job has already finished")
+	ErrNoReport    = errors.New("job did not produce a report")
+	ErrQueueFull   = errors.New("job queue is full")
+	ErrShutdown    = errors.New("job manager is shutting down")
+)
+
+// Progress describes how far a running job has come; Completed and Total
+// count detectors
+type Progress struct {
+	Stage     string `json:"stage"`
+	Completed int    `json:"completed,omitempty"`
+	Total     int    `json:"total,omitempty"`
+}
+
+// Job is a point-in-time view of an analysis job
+type Job struct {
+	ID         string     `json:"id"`
+	RepoName   string     `json:"repo_name"`
+	Status     Status     `json:"status"`
+	Progress   Progress   `json:"progress"`
+	Error      string     `json:"error,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+	StartedAt  *time.Time `json:"started_at,omitempty"`
+	FinishedAt *time.Time `json:"finished_at,omitempty"`
+	Result     *Result    `json:"result,omitempty"` // Set once the job succeeded
+}
+
+// Result is the headline of a succeeded job; the full report is fetched separately
+type Result struct {
+	TotalIssues int     `json:"total_issues"`
+	DebtScore   float64 `json:"debt_score"`
+	DebtGrade   string  `json:"debt_grade"`
+	GatePassed  *bool   `json:"quality_gate_passed,omitempty"`
+}
+
+// Task runs the analysis of a job. It reports progress through the callback
+// and must return promptly once ctx is canceled.
+type Task func(ctx context.Context, progress func(Progress)) (*model.AnalysisReport, error)
+
+// Spec describes a job to submit
+type Spec struct {
+	RepoName string
+	Config   *config.Config // Configuration the job runs with, kept for rendering its report
+	Task     Task
+}
+
+// entry is the manager's record of a job
+type entry struct {
+	job    Job
+	spec   Spec
+	cancel context.CancelFunc
+	report *model.AnalysisReport
+}
+
+// Manager runs jobs on a bounded pool of workers and keeps finished jobs
+// around for a retention period
+type Manager struct {
+	cfg config.ServerConfig
+
+	mu      sync.Mutex
+	jobs    map[string]*entry
+	closed  bool
+	queue   chan *entry
+	ctx     context.Context
+	stop    context.CancelFunc
+	workers sync.WaitGroup
+}
+
+// NewManager creates a new job manager; call Start to begin processing
+func NewManager(cfg config.ServerConfig) *Manager {
+	ctx, stop := context.WithCancel(context.Background())
+	return &Manager{
+		cfg:   cfg,
+		jobs:  make(map[string]*entry),
+		queue: make(chan *entry, max(cfg.QueueSize, 1)),
+		ctx:   ctx,
+		stop:  stop,
+	}
+}
+
+// Start launches the workers and the retention sweeper
+func (m *Manager) Start() {
+	workers := max(m.cfg.Workers, 1)
+	for i := 0; i < workers; i++ {
+		m.workers.Add(1)
+		go m.work()
+	}
+	go m.sweep()
+	util.Info("Job manager started with %d workers (queue size %d)", workers, cap(m.queue))
+}
+
+// Shutdown stops accepting jobs, cancels queued and running ones and waits
+// for the workers to exit or ctx to expire
+func (m *Manager) Shutdown(ctx context.Context) error {
+	m.mu.Lock()
+	if !m.closed {
+		m.closed = true
+		close(m.queue)
+	}
+	m.mu.Unlock()
+	m.stop()
+
+	done := make(chan struct{})
+	go func() {
+		m.workers.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Submit queues a job and returns its initial state
+func (m *Manager) Submit(spec Spec) (Job, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.closed {
+		return Job{}, ErrShutdown
+	}
+
+	e := &entry{
+		job: Job{
+			ID:        newID(),
+			RepoName:  spec.RepoName,
+			Status:    StatusQueued,
+			Progress:  Progress{Stage: string(StatusQueued)},
+			CreatedAt: time.Now().UTC(),
+		},
+		spec: spec,
+	}
+
+	select {
+	case m.queue <- e:
+	default:
+		return Job{}, ErrQueueFull
+	}
+
+	m.jobs[e.job.ID] = e
+	util.Info("Job %s queued for repository %s", e.job.ID, spec.RepoName)
+	return e.job, nil
+}
+
+// Get returns the current state of a job
+func (m *Manager) Get(id string) (Job, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	e, ok := m.jobs[id]
+	if !ok {
+		return Job{}, ErrNotFound
+	}
+	return e.job, nil
+}
+
+// List returns all known jobs, newest first
+func (m *Manager) List() []Job {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	jobs := make([]Job, 0, len(m.jobs))
+	for _, e := range m.jobs {
+		jobs = append(jobs, e.job)
+	}
+	sort.Slice(jobs, func(i, j int) bool {
+		return jobs[i].CreatedAt.After(jobs[j].CreatedAt)
+	})
+	return jobs
+}
+
+// Result returns the report of a succeeded job with the configuration it ran with
+func (m *Manager) Result(id string) (*model.AnalysisReport, *config.Config, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	e, ok := m.jobs[id]
+	if !ok {
+		return nil, nil, ErrNotFound
+	}
+	switch {
+	case !e.job.Status.Finished():
+		return nil, nil, ErrNotFinished
+	case e.job.Status != StatusSucceeded:
+		return nil, nil, fmt.Errorf("%w: %s: %s", ErrNoReport, e.job.Status, e.job.Error)
+	}
+	return e.report, e.spec.Config, nil
+}
+
+// Cancel stops a queued or running job. A queued job is canceled at once; a
+// running job turns canceled when its task returns.
+func (m *Manager) Cancel(id string) (Job, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	e, ok := m.jobs[id]
+	if !ok {
+		return Job{}, ErrNotFound
+	}
+
+	switch e.job.Status {
+	case StatusQueued:
+		m.finish(e, StatusCanceled, "canceled before start")
+	case StatusRunning:
+		e.cancel()
+		e.job.Progress.Stage = "canceling"
+	default:
+		return e.job, ErrFinished
+	}
+
+	util.Info("Job %s cancel requested", id)
+	return e.job, nil
+}
+
+func (m *Manager) work() {
+	defer m.workers.Done()
+	for e := range m.queue {
+		m.run(e)
+	}
+}
+
+func (m *Manager) run(e *entry) {
+	m.mu.Lock()
+	if e.job.Status != StatusQueued {
+		// Canceled while waiting in the queue
+		m.mu.Unlock()
+		return
+	}
+	if m.ctx.Err() != nil {
+		m.finish(e, StatusCanceled, "server shutting down")
+		m.mu.Unlock()
+		return
+	}
+
+	var (
+		ctx    context.Context
+		cancel context.CancelFunc
+	)
+	if m.cfg.JobTimeout > 0 {
+		ctx, cancel = context.WithTimeout(m.ctx, m.cfg.JobTimeout)
+	} else {
+		ctx, cancel = context.WithCancel(m.ctx)
+	}
+	defer cancel()
+
+	now := time.Now().UTC()
+	e.cancel = cancel
+	e.job.Status = StatusRunning
+	e.job.StartedAt = &now
+	e.job.Progress = Progress{Stage: string(StatusRunning)}
+	m.mu.Unlock()
+
+	util.Info("Job %s started", e.job.ID)
+	report, err := e.spec.Task(ctx, func(p Progress) {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		if e.job.Status == StatusRunning && ctx.Err() == nil {
+			e.job.Progress = p
+		}
+	})
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	switch {
+	case err == nil:
+		e.report = report
+		e.job.Result = &Result{
+			TotalIssues: report.Summary.TotalIssues,
+			DebtScore:   report.Summary.DebtScore,
+			DebtGrade:   report.Summary.DebtGrade,
+		}
+		if report.QualityGate != nil {
+			e.job.Result.GatePassed = &report.QualityGate.Passed
+		}
+		m.finish(e, StatusSucceeded, "")
+	case errors.Is(ctx.Err(), context.Canceled):
+		m.finish(e, StatusCanceled, err.Error())
+	default:
+		m.finish(e, StatusFailed, err.Error())
+	}
+}
+
+// finish moves a job to a terminal state; m.mu must be held
+func (m *Manager) finish(e *entry, status Status, message string) {
+	now := time.Now().UTC()
+	e.job.Status = status
+	e.job.Error = message
+	e.job.FinishedAt = &now
+	e.job.Progress = Progress{Stage: string(status)}
+
+	if status == StatusFailed {
+		util.Warn("Job %s failed: %s", e.job.ID, message)
+	} else {
+		util.Info("Job %s %s", e.job.ID, status)
+	}
+}
+
+// sweep periodically drops finished jobs past their retention
+func (m *Manager) sweep() {
+	interval := time.Minute
+	if m.cfg.JobRetention > 0 && m.cfg.JobRetention < interval {
+		interval = m.cfg.JobRetention
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-m.ctx.Done():
+			return
+		case <-ticker.C:
+			m.prune(time.Now())
+		}
+	}
+}
+
+// prune removes finished jobs older than the retention period and, beyond
+// MaxFinishedJobs, the oldest finished jobs
+func (m *Manager) prune(now time.Time) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var finished []*entry
+	for id, e := range m.jobs {
+		if !e.job.Status.Finished() {
+			continue
+		}
+		if m.cfg.JobRetention > 0 && now.Sub(*e.job.FinishedAt) > m.cfg.JobRetention {
+			delete(m.jobs, id)
+			continue
+		}
+		finished = append(finished, e)
+	}
+
+	if m.cfg.MaxFinishedJobs > 0 && len(finished) > m.cfg.MaxFinishedJobs {
+		sort.Slice(finished, func(i, j int) bool {
+			return finished[i].job.FinishedAt.Before(*finished[j].job.FinishedAt)
+		})
+		for _, e := range finished[:len(finished)-m.cfg.MaxFinishedJobs] {
+			delete(m.jobs, e.job.ID)
+		}
+	}
+}
+
+func newID() string {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return fmt.Sprintf("%x", time.Now().UnixNano())
+	}
+	return hex.EncodeToString(b)
+}
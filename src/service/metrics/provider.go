@@ -8,6 +8,8 @@ import (
 	"quality-bot/src/config"
 	"quality-bot/src/model"
 	"quality-bot/src/service/codeapi"
+	"quality-bot/src/service/progress"
+	"quality-bot/src/service/telemetry"
 	"quality-bot/src/util"
 )
 
@@ -24,6 +26,10 @@ type Provider struct {
 	classMetrics     []model.ClassMetrics
 	fileMetrics      []model.FileMetrics
 	classPairMetrics []model.ClassPairMetrics
+
+	// Raw query results by metric kind, kept only when requested
+	keepRows bool
+	rawRows  map[string][]map[string]any
 }
 
 // NewProvider creates a new metrics provider
@@ -40,12 +46,37 @@ func (p *Provider) RepoName() string {
 	return p.repoName
 }
 
+// KeepRawRows makes the provider retain the rows CodeAPI returns, so the
+// numbers behind a metric can be inspected with RawRows
+func (p *Provider) KeepRawRows() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.keepRows = true
+	p.rawRows = make(map[string][]map[string]any)
+}
+
+// RawRows returns the rows last fetched for a metric kind: function, class,
+// file or class_pair. It is empty unless KeepRawRows was called first.
+func (p *Provider) RawRows(kind string) []map[string]any {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.rawRows[kind]
+}
+
+// recordRows retains query results if requested; callers hold the write lock
+func (p *Provider) recordRows(kind string, rows []map[string]any) {
+	if p.keepRows {
+		p.rawRows[kind] = rows
+	}
+}
+
 // GetAllFunctionMetrics retrieves metrics for all functions
 func (p *Provider) GetAllFunctionMetrics(ctx context.Context) ([]model.FunctionMetrics, error) {
 	p.mu.RLock()
 	if p.functionMetrics != nil {
 		defer p.mu.RUnlock()
 		util.Debug("Returning %d cached function metrics", len(p.functionMetrics))
+		telemetry.CountCacheLookup("function", true)
 		return p.functionMetrics, nil
 	}
 	p.mu.RUnlock()
@@ -56,10 +87,12 @@ func (p *Provider) GetAllFunctionMetrics(ctx context.Context) ([]model.FunctionM
 	// Double-check after acquiring write lock
 	if p.functionMetrics != nil {
 		util.Debug("Returning %d cached function metrics (after lock upgrade)", len(p.functionMetrics))
+		telemetry.CountCacheLookup("function", true)
 		return p.functionMetrics, nil
 	}
 
 	util.Debug("Fetching function metrics from CodeAPI")
+	telemetry.CountCacheLookup("function", false)
 	metrics, err := p.fetchFunctionMetrics(ctx)
 	if err != nil {
 		util.Error("Failed to fetch function metrics: %v", err)
@@ -67,6 +100,7 @@ func (p *Provider) GetAllFunctionMetrics(ctx context.Context) ([]model.FunctionM
 	}
 
 	util.Info("Retrieved %d function metrics", len(metrics))
+	progress.Emit(ctx, progress.Event{Type: progress.MetricsFetched, Kind: "function", Rows: len(metrics)})
 	if p.cfg.Enabled {
 		p.functionMetrics = metrics
 		util.Debug("Function metrics cached")
@@ -76,59 +110,11 @@ func (p *Provider) GetAllFunctionMetrics(ctx context.Context) ([]model.FunctionM
 }
 
 func (p *Provider) fetchFunctionMetrics(ctx context.Context) ([]model.FunctionMetrics, error) {
-	query := `
-	MATCH (fs:FileScope)-[:CONTAINS*]->(f:Function)
-	WHERE fs.repo = $repo_name
-
-	OPTIONAL MATCH (c:Class)-[:CONTAINS]->(f)
-	OPTIONAL MATCH (f)-[:CONTAINS*]->(cond:Conditional)
-	OPTIONAL MATCH (f)-[:CONTAINS*]->(loop:Loop)
-	OPTIONAL MATCH (f)-[:CONTAINS*]->(:Conditional)-[br:BRANCH]->()
-	// Count nesting by finding paths and counting only Conditional/Loop nodes in them
-	OPTIONAL MATCH path = (f)-[:CONTAINS*]->(deepest)
-	WHERE deepest:Conditional OR deepest:Loop
-	OPTIONAL MATCH (caller:Function)-[:CALLS]->(f)
-	OPTIONAL MATCH (f)-[:CALLS]->(callee:Function)
-	OPTIONAL MATCH (f)-[:CALLS]->(ext:Function)<-[:CONTAINS]-(other:Class)
-	WHERE other <> c
-	OPTIONAL MATCH (f)-[:USES]->(own_field:Field)<-[:CONTAINS]-(c)
-	OPTIONAL MATCH (f)-[:USES]->(ext_field:Field)<-[:CONTAINS]-(ext_class:Class)
-	WHERE ext_class <> c
-
-	WITH fs, f, c,
-	     count(DISTINCT cond) as conditional_count,
-	     count(DISTINCT loop) as loop_count,
-	     count(DISTINCT br) as branch_count,
-	     max(size([n IN nodes(path) WHERE n:Conditional OR n:Loop])) as max_nesting_depth,
-	     count(DISTINCT caller) as caller_count,
-	     count(DISTINCT callee) as callee_count,
-	     count(DISTINCT other) as external_calls,
-	     count(DISTINCT own_field) as own_field_uses,
-	     count(DISTINCT ext_field) as external_field_uses
-
-	RETURN
-	    f.id as id,
-	    f.name as name,
-	    fs.path as file_path,
-	    f.range as range,
-	    c.name as class_name,
-	    COALESCE(f.param_count, 0) as parameter_count,
-	    (1 + loop_count + branch_count) as cyclomatic_complexity,
-	    conditional_count,
-	    loop_count,
-	    branch_count,
-	    COALESCE(max_nesting_depth, 0) as max_nesting_depth,
-	    caller_count,
-	    callee_count,
-	    external_calls,
-	    own_field_uses,
-	    external_field_uses
-	`
-
-	results, err := p.client.ExecuteCypher(ctx, p.repoName, query)
+	results, err := p.client.ExecuteCypher(ctx, p.repoName, functionMetricsQuery)
 	if err != nil {
 		return nil, err
 	}
+	p.recordRows("function", results)
 
 	metrics := make([]model.FunctionMetrics, 0, len(results))
 	for _, r := range results {
@@ -169,6 +155,7 @@ func (p *Provider) GetAllClassMetrics(ctx context.Context) ([]model.ClassMetrics
 	if p.classMetrics != nil {
 		defer p.mu.RUnlock()
 		util.Debug("Returning %d cached class metrics", len(p.classMetrics))
+		telemetry.CountCacheLookup("class", true)
 		return p.classMetrics, nil
 	}
 	p.mu.RUnlock()
@@ -178,10 +165,12 @@ func (p *Provider) GetAllClassMetrics(ctx context.Context) ([]model.ClassMetrics
 
 	if p.classMetrics != nil {
 		util.Debug("Returning %d cached class metrics (after lock upgrade)", len(p.classMetrics))
+		telemetry.CountCacheLookup("class", true)
 		return p.classMetrics, nil
 	}
 
 	util.Debug("Fetching class metrics from CodeAPI")
+	telemetry.CountCacheLookup("class", false)
 	metrics, err := p.fetchClassMetrics(ctx)
 	if err != nil {
 		util.Error("Failed to fetch class metrics: %v", err)
@@ -189,6 +178,7 @@ func (p *Provider) GetAllClassMetrics(ctx context.Context) ([]model.ClassMetrics
 	}
 
 	util.Info("Retrieved %d class metrics", len(metrics))
+	progress.Emit(ctx, progress.Event{Type: progress.MetricsFetched, Kind: "class", Rows: len(metrics)})
 	if p.cfg.Enabled {
 		p.classMetrics = metrics
 		util.Debug("Class metrics cached")
@@ -198,45 +188,11 @@ func (p *Provider) GetAllClassMetrics(ctx context.Context) ([]model.ClassMetrics
 }
 
 func (p *Provider) fetchClassMetrics(ctx context.Context) ([]model.ClassMetrics, error) {
-	query := `
-	MATCH (fs:FileScope)-[:CONTAINS]->(c:Class)
-	WHERE fs.repo = $repo_name
-
-	OPTIONAL MATCH (c)-[:CONTAINS]->(m:Function)
-	OPTIONAL MATCH (c)-[:CONTAINS]->(f:Field)
-	OPTIONAL MATCH (c)-[:CONTAINS]->(pf:Field)
-	WHERE pf.type IN ['string', 'int', 'float', 'bool', 'int64', 'float64', 'String', 'Integer', 'Boolean', 'Double']
-	OPTIONAL MATCH (c)-[:CONTAINS]->(:Function)-[:CALLS]->(:Function)<-[:CONTAINS]-(dep:Class)
-	WHERE dep <> c
-	OPTIONAL MATCH (other:Class)-[:CONTAINS]->(:Function)-[:CALLS]->(:Function)<-[:CONTAINS]-(c)
-	WHERE other <> c
-	OPTIONAL MATCH inheritance_path = (c)-[:INHERITS_FROM*]->(parent:Class)
-
-	WITH fs, c,
-	     count(DISTINCT m) as method_count,
-	     count(DISTINCT f) as field_count,
-	     count(DISTINCT pf) as primitive_field_count,
-	     count(DISTINCT dep) as dependency_count,
-	     count(DISTINCT other) as dependent_count,
-	     max(length(inheritance_path)) as inheritance_depth
-
-	RETURN
-	    c.id as id,
-	    c.name as name,
-	    fs.path as file_path,
-	    c.range as range,
-	    method_count,
-	    field_count,
-	    primitive_field_count,
-	    dependency_count,
-	    dependent_count,
-	    COALESCE(inheritance_depth, 0) as inheritance_depth
-	`
-
-	results, err := p.client.ExecuteCypher(ctx, p.repoName, query)
+	results, err := p.client.ExecuteCypher(ctx, p.repoName, classMetricsQuery)
 	if err != nil {
 		return nil, err
 	}
+	p.recordRows("class", results)
 
 	metrics := make([]model.ClassMetrics, 0, len(results))
 	for _, r := range results {
@@ -271,6 +227,7 @@ func (p *Provider) GetAllFileMetrics(ctx context.Context) ([]model.FileMetrics,
 	if p.fileMetrics != nil {
 		defer p.mu.RUnlock()
 		util.Debug("Returning %d cached file metrics", len(p.fileMetrics))
+		telemetry.CountCacheLookup("file", true)
 		return p.fileMetrics, nil
 	}
 	p.mu.RUnlock()
@@ -280,10 +237,12 @@ func (p *Provider) GetAllFileMetrics(ctx context.Context) ([]model.FileMetrics,
 
 	if p.fileMetrics != nil {
 		util.Debug("Returning %d cached file metrics (after lock upgrade)", len(p.fileMetrics))
+		telemetry.CountCacheLookup("file", true)
 		return p.fileMetrics, nil
 	}
 
 	util.Debug("Fetching file metrics from CodeAPI")
+	telemetry.CountCacheLookup("file", false)
 	metrics, err := p.fetchFileMetrics(ctx)
 	if err != nil {
 		util.Error("Failed to fetch file metrics: %v", err)
@@ -291,6 +250,7 @@ func (p *Provider) GetAllFileMetrics(ctx context.Context) ([]model.FileMetrics,
 	}
 
 	util.Info("Retrieved %d file metrics", len(metrics))
+	progress.Emit(ctx, progress.Event{Type: progress.MetricsFetched, Kind: "file", Rows: len(metrics)})
 	if p.cfg.Enabled {
 		p.fileMetrics = metrics
 		util.Debug("File metrics cached")
@@ -300,44 +260,11 @@ func (p *Provider) GetAllFileMetrics(ctx context.Context) ([]model.FileMetrics,
 }
 
 func (p *Provider) fetchFileMetrics(ctx context.Context) ([]model.FileMetrics, error) {
-	query := `
-	MATCH (fs:FileScope)
-	WHERE fs.repo = $repo_name
-
-	OPTIONAL MATCH (fs)-[:CONTAINS]->(f:Function)
-	OPTIONAL MATCH (fs)-[:CONTAINS]->(c:Class)
-
-	WITH fs,
-	     count(DISTINCT f) as function_count,
-	     count(DISTINCT c) as class_count,
-	     collect(DISTINCT f) as functions
-
-	// Calculate complexity for each function
-	UNWIND CASE WHEN size(functions) > 0 THEN functions ELSE [null] END as func
-	OPTIONAL MATCH (func)-[:CONTAINS*]->(loop:Loop)
-	OPTIONAL MATCH (func)-[:CONTAINS*]->(:Conditional)-[br:BRANCH]->()
-
-	WITH fs, function_count, class_count, func,
-	     CASE WHEN func IS NOT NULL THEN 1 + count(DISTINCT loop) + count(DISTINCT br) ELSE 0 END as cc
-
-	WITH fs, function_count, class_count,
-	     sum(cc) as total_cyclomatic_complexity,
-	     max(cc) as max_function_complexity
-
-	RETURN
-	    fs.path as path,
-	    fs.language as language,
-	    fs.range as range,
-	    function_count,
-	    class_count,
-	    total_cyclomatic_complexity,
-	    max_function_complexity
-	`
-
-	results, err := p.client.ExecuteCypher(ctx, p.repoName, query)
+	results, err := p.client.ExecuteCypher(ctx, p.repoName, fileMetricsQuery)
 	if err != nil {
 		return nil, err
 	}
+	p.recordRows("file", results)
 
 	metrics := make([]model.FileMetrics, 0, len(results))
 	for _, r := range results {
@@ -372,6 +299,7 @@ func (p *Provider) GetClassPairMetrics(ctx context.Context) ([]model.ClassPairMe
 	if p.classPairMetrics != nil {
 		defer p.mu.RUnlock()
 		util.Debug("Returning %d cached class pair metrics", len(p.classPairMetrics))
+		telemetry.CountCacheLookup("class_pair", true)
 		return p.classPairMetrics, nil
 	}
 	p.mu.RUnlock()
@@ -381,10 +309,12 @@ func (p *Provider) GetClassPairMetrics(ctx context.Context) ([]model.ClassPairMe
 
 	if p.classPairMetrics != nil {
 		util.Debug("Returning %d cached class pair metrics (after lock upgrade)", len(p.classPairMetrics))
+		telemetry.CountCacheLookup("class_pair", true)
 		return p.classPairMetrics, nil
 	}
 
 	util.Debug("Fetching class pair metrics from CodeAPI")
+	telemetry.CountCacheLookup("class_pair", false)
 	metrics, err := p.fetchClassPairMetrics(ctx)
 	if err != nil {
 		util.Error("Failed to fetch class pair metrics: %v", err)
@@ -392,6 +322,7 @@ func (p *Provider) GetClassPairMetrics(ctx context.Context) ([]model.ClassPairMe
 	}
 
 	util.Info("Retrieved %d class pair metrics", len(metrics))
+	progress.Emit(ctx, progress.Event{Type: progress.MetricsFetched, Kind: "class_pair", Rows: len(metrics)})
 	if p.cfg.Enabled {
 		p.classPairMetrics = metrics
 		util.Debug("Class pair metrics cached")
@@ -401,38 +332,11 @@ func (p *Provider) GetClassPairMetrics(ctx context.Context) ([]model.ClassPairMe
 }
 
 func (p *Provider) fetchClassPairMetrics(ctx context.Context) ([]model.ClassPairMetrics, error) {
-	query := `
-	MATCH (fs1:FileScope)-[:CONTAINS]->(c1:Class)-[:CONTAINS]->(f1:Function)-[:CALLS]->(f2:Function)<-[:CONTAINS]-(c2:Class)<-[:CONTAINS]-(fs2:FileScope)
-	WHERE c1 <> c2 AND fs1.repo = $repo_name AND fs2.repo = $repo_name
-
-	WITH c1, c2, count(*) as calls_1_to_2
-
-	OPTIONAL MATCH (c2)-[:CONTAINS]->(f3:Function)-[:CALLS]->(f4:Function)<-[:CONTAINS]-(c1)
-
-	WITH c1, c2, calls_1_to_2, count(f3) as calls_2_to_1
-
-	OPTIONAL MATCH (c1)-[:CONTAINS]->(:Function)-[:USES]->(field:Field)<-[:CONTAINS]-(c2)
-	OPTIONAL MATCH (c2)-[:CONTAINS]->(:Function)-[:USES]->(field2:Field)<-[:CONTAINS]-(c1)
-
-	WITH c1, c2, calls_1_to_2, calls_2_to_1,
-	     count(DISTINCT field) + count(DISTINCT field2) as shared_field_access
-
-	WHERE calls_1_to_2 > 0 OR calls_2_to_1 > 0
-
-	RETURN
-	    c1.name as class1_name,
-	    c1.file_path as class1_file,
-	    c2.name as class2_name,
-	    c2.file_path as class2_file,
-	    calls_1_to_2,
-	    calls_2_to_1,
-	    shared_field_access
-	`
-
-	results, err := p.client.ExecuteCypher(ctx, p.repoName, query)
+	results, err := p.client.ExecuteCypher(ctx, p.repoName, classPairMetricsQuery)
 	if err != nil {
 		return nil, err
 	}
+	p.recordRows("class_pair", results)
 
 	metrics := make([]model.ClassPairMetrics, 0, len(results))
 	for _, r := range results {
@@ -0,0 +1,171 @@
+package metrics
+
+// Cypher queries behind the provider's metrics, run with $repo_name bound
+// to the analyzed repository
+const (
+	// functionMetricsQuery returns function metrics
+	functionMetricsQuery = `
+	MATCH (fs:FileScope)-[:CONTAINS*]->(f:Function)
+	WHERE fs.repo = $repo_name
+
+	OPTIONAL MATCH (c:Class)-[:CONTAINS]->(f)
+	OPTIONAL MATCH (f)-[:CONTAINS*]->(cond:Conditional)
+	OPTIONAL MATCH (f)-[:CONTAINS*]->(loop:Loop)
+	OPTIONAL MATCH (f)-[:CONTAINS*]->(:Conditional)-[br:BRANCH]->()
+	// Count nesting by finding paths and counting only Conditional/Loop nodes in them
+	OPTIONAL MATCH path = (f)-[:CONTAINS*]->(deepest)
+	WHERE deepest:Conditional OR deepest:Loop
+	OPTIONAL MATCH (caller:Function)-[:CALLS]->(f)
+	OPTIONAL MATCH (f)-[:CALLS]->(callee:Function)
+	OPTIONAL MATCH (f)-[:CALLS]->(ext:Function)<-[:CONTAINS]-(other:Class)
+	WHERE other <> c
+	OPTIONAL MATCH (f)-[:USES]->(own_field:Field)<-[:CONTAINS]-(c)
+	OPTIONAL MATCH (f)-[:USES]->(ext_field:Field)<-[:CONTAINS]-(ext_class:Class)
+	WHERE ext_class <> c
+
+	WITH fs, f, c,
+	     count(DISTINCT cond) as conditional_count,
+	     count(DISTINCT loop) as loop_count,
+	     count(DISTINCT br) as branch_count,
+	     max(size([n IN nodes(path) WHERE n:Conditional OR n:Loop])) as max_nesting_depth,
+	     count(DISTINCT caller) as caller_count,
+	     count(DISTINCT callee) as callee_count,
+	     count(DISTINCT other) as external_calls,
+	     count(DISTINCT own_field) as own_field_uses,
+	     count(DISTINCT ext_field) as external_field_uses
+
+	RETURN
+	    f.id as id,
+	    f.name as name,
+	    fs.path as file_path,
+	    f.range as range,
+	    c.name as class_name,
+	    COALESCE(f.param_count, 0) as parameter_count,
+	    (1 + loop_count + branch_count) as cyclomatic_complexity,
+	    conditional_count,
+	    loop_count,
+	    branch_count,
+	    COALESCE(max_nesting_depth, 0) as max_nesting_depth,
+	    caller_count,
+	    callee_count,
+	    external_calls,
+	    own_field_uses,
+	    external_field_uses
+	`
+
+	// classMetricsQuery returns class metrics
+	classMetricsQuery = `
+	MATCH (fs:FileScope)-[:CONTAINS]->(c:Class)
+	WHERE fs.repo = $repo_name
+
+	OPTIONAL MATCH (c)-[:CONTAINS]->(m:Function)
+	OPTIONAL MATCH (c)-[:CONTAINS]->(f:Field)
+	OPTIONAL MATCH (c)-[:CONTAINS]->(pf:Field)
+	WHERE pf.type IN ['string', 'int', 'float', 'bool', 'int64', 'float64', 'String', 'Integer', 'Boolean', 'Double']
+	OPTIONAL MATCH (c)-[:CONTAINS]->(:Function)-[:CALLS]->(:Function)<-[:CONTAINS]-(dep:Class)
+	WHERE dep <> c
+	OPTIONAL MATCH (other:Class)-[:CONTAINS]->(:Function)-[:CALLS]->(:Function)<-[:CONTAINS]-(c)
+	WHERE other <> c
+	OPTIONAL MATCH inheritance_path = (c)-[:INHERITS_FROM*]->(parent:Class)
+
+	WITH fs, c,
+	     count(DISTINCT m) as method_count,
+	     count(DISTINCT f) as field_count,
+	     count(DISTINCT pf) as primitive_field_count,
+	     count(DISTINCT dep) as dependency_count,
+	     count(DISTINCT other) as dependent_count,
+	     max(length(inheritance_path)) as inheritance_depth
+
+	RETURN
+	    c.id as id,
+	    c.name as name,
+	    fs.path as file_path,
+	    c.range as range,
+	    method_count,
+	    field_count,
+	    primitive_field_count,
+	    dependency_count,
+	    dependent_count,
+	    COALESCE(inheritance_depth, 0) as inheritance_depth
+	`
+
+	// fileMetricsQuery returns file metrics
+	fileMetricsQuery = `
+	MATCH (fs:FileScope)
+	WHERE fs.repo = $repo_name
+
+	OPTIONAL MATCH (fs)-[:CONTAINS]->(f:Function)
+	OPTIONAL MATCH (fs)-[:CONTAINS]->(c:Class)
+
+	WITH fs,
+	     count(DISTINCT f) as function_count,
+	     count(DISTINCT c) as class_count,
+	     collect(DISTINCT f) as functions
+
+	// Calculate complexity for each function
+	UNWIND CASE WHEN size(functions) > 0 THEN functions ELSE [null] END as func
+	OPTIONAL MATCH (func)-[:CONTAINS*]->(loop:Loop)
+	OPTIONAL MATCH (func)-[:CONTAINS*]->(:Conditional)-[br:BRANCH]->()
+
+	WITH fs, function_count, class_count, func,
+	     CASE WHEN func IS NOT NULL THEN 1 + count(DISTINCT loop) + count(DISTINCT br) ELSE 0 END as cc
+
+	WITH fs, function_count, class_count,
+	     sum(cc) as total_cyclomatic_complexity,
+	     max(cc) as max_function_complexity
+
+	RETURN
+	    fs.path as path,
+	    fs.language as language,
+	    fs.range as range,
+	    function_count,
+	    class_count,
+	    total_cyclomatic_complexity,
+	    max_function_complexity
+	`
+
+	// classPairMetricsQuery returns coupling between class pairs
+	classPairMetricsQuery = `
+	MATCH (fs1:FileScope)-[:CONTAINS]->(c1:Class)-[:CONTAINS]->(f1:Function)-[:CALLS]->(f2:Function)<-[:CONTAINS]-(c2:Class)<-[:CONTAINS]-(fs2:FileScope)
+	WHERE c1 <> c2 AND fs1.repo = $repo_name AND fs2.repo = $repo_name
+
+	WITH c1, c2, count(*) as calls_1_to_2
+
+	OPTIONAL MATCH (c2)-[:CONTAINS]->(f3:Function)-[:CALLS]->(f4:Function)<-[:CONTAINS]-(c1)
+
+	WITH c1, c2, calls_1_to_2, count(f3) as calls_2_to_1
+
+	OPTIONAL MATCH (c1)-[:CONTAINS]->(:Function)-[:USES]->(field:Field)<-[:CONTAINS]-(c2)
+	OPTIONAL MATCH (c2)-[:CONTAINS]->(:Function)-[:USES]->(field2:Field)<-[:CONTAINS]-(c1)
+
+	WITH c1, c2, calls_1_to_2, calls_2_to_1,
+	     count(DISTINCT field) + count(DISTINCT field2) as shared_field_access
+
+	WHERE calls_1_to_2 > 0 OR calls_2_to_1 > 0
+
+	RETURN
+	    c1.name as class1_name,
+	    c1.file_path as class1_file,
+	    c2.name as class2_name,
+	    c2.file_path as class2_file,
+	    calls_1_to_2,
+	    calls_2_to_1,
+	    shared_field_access
+	`
+)
+
+// Query returns the Cypher query behind a metric kind: function, class, file
+// or class_pair
+func Query(kind string) string {
+	switch kind {
+	case "function":
+		return functionMetricsQuery
+	case "class":
+		return classMetricsQuery
+	case "file":
+		return fileMetricsQuery
+	case "class_pair":
+		return classPairMetricsQuery
+	}
+	return ""
+}
@@ -0,0 +1,209 @@
+package ownership
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"quality-bot/src/model"
+	"quality-bot/src/util"
+)
+
+// UnownedKey groups issues in files no CODEOWNERS rule matches
+const UnownedKey = "(unowned)"
+
+// candidatePaths are the locations GitHub and GitLab look for CODEOWNERS,
+// relative to the repository root
+var candidatePaths = []string{".github/CODEOWNERS", "CODEOWNERS", "docs/CODEOWNERS", ".gitlab/CODEOWNERS"}
+
+// sectionHeader matches a GitLab section header with optional approval count
+// and default owners, e.g. "[Backend][2] @backend-team" or "^[Docs] @writers"
+var sectionHeader = regexp.MustCompile(`^\^?\[([^\]]+)\](?:\[\d+\])?(.*)$`)
+
+// rule is one CODEOWNERS line; a path is owned by a rule if any matcher matches
+type rule struct {
+	pattern  string
+	matchers []*regexp.Regexp
+	owners   []string
+}
+
+// CodeOwners resolves file owners from a CODEOWNERS file
+type CodeOwners struct {
+	rules []rule
+}
+
+// Find returns the CODEOWNERS file of the checkout at root, or "" if there is none
+func Find(root string) string {
+	for _, p := range candidatePaths {
+		full := filepath.Join(root, p)
+		if info, err := os.Stat(full); err == nil && !info.IsDir() {
+			return full
+		}
+	}
+	return ""
+}
+
+// Load reads and parses a CODEOWNERS file
+func Load(path string) (*CodeOwners, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("opening CODEOWNERS: %w", err)
+	}
+	defer f.Close()
+
+	co, err := Parse(f)
+	if err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	util.Debug("Loaded %d CODEOWNERS rules from %s", len(co.rules), path)
+	return co, nil
+}
+
+// Parse reads CODEOWNERS rules in GitHub/GitLab syntax. Rules in a GitLab
+// section ("[Section] @owner", "^[Optional]", "[Section][2]") apply like any
+// other, and those without owners get the section's default owners. Outside
+// sections, a pattern without owners removes ownership for matching paths.
+func Parse(r io.Reader) (*CodeOwners, error) {
+	co := &CodeOwners{}
+	scanner := bufio.NewScanner(r)
+	lineNo := 0
+
+	var sectionOwners []string
+	for scanner.Scan() {
+		lineNo++
+		line := strings.TrimSpace(stripComment(scanner.Text()))
+		if line == "" {
+			continue
+		}
+		if strings.HasPrefix(line, "[") || strings.HasPrefix(line, "^[") {
+			m := sectionHeader.FindStringSubmatch(line)
+			if m == nil {
+				util.Warn("CODEOWNERS line %d: ignoring invalid section header %q", lineNo, line)
+				sectionOwners = nil
+				continue
+			}
+			sectionOwners = nil
+			for _, owner := range strings.Fields(m[2]) {
+				// Users and groups ("@team") or email addresses
+				if strings.Contains(owner, "@") {
+					sectionOwners = append(sectionOwners, owner)
+				}
+			}
+			continue
+		}
+
+		fields := strings.Fields(line)
+		matchers, err := compilePattern(fields[0])
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid pattern %q: %w", lineNo, fields[0], err)
+		}
+		owners := fields[1:]
+		if len(owners) == 0 {
+			owners = sectionOwners
+		}
+		co.rules = append(co.rules, rule{pattern: fields[0], matchers: matchers, owners: owners})
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return co, nil
+}
+
+// stripComment removes a trailing "#" comment; "\#" is a literal hash
+func stripComment(line string) string {
+	for i := 0; i < len(line); i++ {
+		if line[i] == '#' && (i == 0 || line[i-1] != '\\') {
+			return line[:i]
+		}
+	}
+	return line
+}
+
+// compilePattern turns a gitignore-style CODEOWNERS pattern into path
+// matchers. Patterns with a leading or inner "/" are anchored at the root,
+// others match at any depth. A pattern naming a directory owns everything
+// below it, except "dir/*" which only owns the directory's direct files.
+func compilePattern(pattern string) ([]*regexp.Regexp, error) {
+	dirOnly := strings.HasSuffix(pattern, "/")
+	p := strings.TrimSuffix(pattern, "/")
+
+	if strings.HasPrefix(p, "/") || strings.Contains(p, "/") {
+		p = strings.TrimPrefix(p, "/")
+	} else if !strings.HasPrefix(p, "**") {
+		p = "**/" + p
+	}
+
+	globs := []string{p + "/**"}
+	if !dirOnly {
+		globs = append(globs, p)
+		if strings.HasSuffix(p, "/*") {
+			globs = globs[1:]
+		}
+	}
+
+	var matchers []*regexp.Regexp
+	for _, g := range globs {
+		re, err := util.CompileGlob(g)
+		if err != nil {
+			return nil, err
+		}
+		matchers = append(matchers, re)
+	}
+	return matchers, nil
+}
+
+// Owners returns the owners of a repository-relative path. The last matching
+// rule wins, as on GitHub and GitLab.
+func (co *CodeOwners) Owners(filePath string) []string {
+	filePath = strings.TrimPrefix(strings.TrimPrefix(filePath, "./"), "/")
+
+	for i := len(co.rules) - 1; i >= 0; i-- {
+		for _, re := range co.rules[i].matchers {
+			if re.MatchString(filePath) {
+				return co.rules[i].owners
+			}
+		}
+	}
+	return nil
+}
+
+// Apply sets Owners on every issue
+func (co *CodeOwners) Apply(issues []model.DebtIssue) []model.DebtIssue {
+	for i := range issues {
+		issues[i].Owners = co.Owners(issues[i].FilePath)
+	}
+	return issues
+}
+
+// CountByOwner counts issues per owner; an issue with several owners counts
+// for each of them
+func CountByOwner(issues []model.DebtIssue) map[string]int {
+	counts := make(map[string]int)
+	for _, issue := range issues {
+		if len(issue.Owners) == 0 {
+			counts[UnownedKey]++
+		}
+		for _, o := range issue.Owners {
+			counts[o]++
+		}
+	}
+	return counts
+}
+
+// OwnedBy reports whether any of the owners is in the wanted set. Owner
+// names are compared case-insensitively, as GitHub treats them.
+func OwnedBy(owners, wanted []string) bool {
+	for _, o := range owners {
+		for _, w := range wanted {
+			if strings.EqualFold(o, w) {
+				return true
+			}
+		}
+	}
+	return false
+}
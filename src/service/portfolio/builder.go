@@ -0,0 +1,110 @@
+package portfolio
+
+import (
+	"sort"
+	"time"
+
+	"quality-bot/src/model"
+	"quality-bot/src/service/scoring"
+)
+
+// Result is the outcome of analyzing one repository of a batch
+type Result struct {
+	RepoName string
+	Report   *model.AnalysisReport // Nil if the analysis failed
+	Err      error
+	Reports  []string // Paths of the written per-repository reports
+}
+
+// Build ranks the repositories by size-normalized debt score and compares
+// their category mix. The portfolio score is computed over the pooled issues
+// and lines of all successfully analyzed repositories.
+func Build(results []Result, scorer *scoring.Scorer) *model.PortfolioReport {
+	p := &model.PortfolioReport{
+		GeneratedAt: time.Now().UTC(),
+		CategoryMix: make(map[model.Category]float64),
+	}
+
+	var (
+		analyzed   []model.PortfolioEntry
+		failed     []model.PortfolioEntry
+		weight     float64
+		byCategory = make(map[model.Category]int)
+	)
+
+	for _, r := range results {
+		if r.Err != nil || r.Report == nil {
+			entry := model.PortfolioEntry{RepoName: r.RepoName}
+			if r.Err != nil {
+				entry.Error = r.Err.Error()
+			}
+			failed = append(failed, entry)
+			continue
+		}
+
+		s := r.Report.Summary
+		entry := model.PortfolioEntry{
+			RepoName:    r.RepoName,
+			TotalIssues: s.TotalIssues,
+			LinesOfCode: s.LinesOfCode,
+			DebtScore:   s.DebtScore,
+			DebtGrade:   s.DebtGrade,
+			ByCategory:  s.ByCategory,
+			BySeverity:  s.BySeverity,
+			CategoryMix: shares(s.ByCategory, s.TotalIssues),
+			Reports:     r.Reports,
+		}
+		if s.Remediation != nil {
+			entry.RemediationMinutes = s.Remediation.TotalMinutes
+			entry.DebtRatio = s.Remediation.DebtRatio
+		}
+		if r.Report.QualityGate != nil {
+			entry.GatePassed = &r.Report.QualityGate.Passed
+		}
+		analyzed = append(analyzed, entry)
+
+		// The summary weighs all issues, not only those listed in the report
+		weight += s.WeightedIssues
+		for cat, n := range s.ByCategory {
+			byCategory[cat] += n
+		}
+		p.Totals.TotalIssues += s.TotalIssues
+		p.Totals.LinesOfCode += s.LinesOfCode
+		p.Totals.RemediationMinutes += entry.RemediationMinutes
+	}
+
+	sort.Slice(analyzed, func(i, j int) bool {
+		if analyzed[i].DebtScore != analyzed[j].DebtScore {
+			return analyzed[i].DebtScore > analyzed[j].DebtScore
+		}
+		return analyzed[i].RepoName < analyzed[j].RepoName
+	})
+	for i := range analyzed {
+		analyzed[i].Rank = i + 1
+	}
+	sort.Slice(failed, func(i, j int) bool {
+		return failed[i].RepoName < failed[j].RepoName
+	})
+
+	p.Repos = append(analyzed, failed...)
+	p.Totals.Analyzed = len(analyzed)
+	p.Totals.Failed = len(failed)
+	if len(analyzed) > 0 {
+		p.Totals.DebtScore = scorer.Score(weight, p.Totals.LinesOfCode)
+		p.Totals.DebtGrade = scorer.Grade(p.Totals.DebtScore)
+	}
+	p.CategoryMix = shares(byCategory, p.Totals.TotalIssues)
+	return p
+}
+
+// shares converts category counts into percentages of total
+func shares(counts map[model.Category]int, total int) map[model.Category]float64 {
+	mix := make(map[model.Category]float64, len(counts))
+	if total == 0 {
+		return mix
+	}
+	for cat, n := range counts {
+		mix[cat] = float64(n) / float64(total) * 100
+	}
+	return mix
+}
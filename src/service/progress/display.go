@@ -0,0 +1,70 @@
+package progress
+
+import (
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+// redrawInterval throttles redraws of the progress line
+const redrawInterval = 100 * time.Millisecond
+
+// Display renders a live, single-line progress view for a terminal. With
+// several repositories it shows how many are done and the most recently
+// active one.
+type Display struct {
+	w io.Writer
+
+	mu       sync.Mutex
+	trackers map[string]*Tracker
+	done     int
+	lastDraw time.Time
+	drawn    bool
+}
+
+// NewDisplay creates a display writing to w, normally stderr
+func NewDisplay(w io.Writer) *Display {
+	return &Display{w: w, trackers: make(map[string]*Tracker)}
+}
+
+// Emit updates the view with an event
+func (d *Display) Emit(e Event) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	t, ok := d.trackers[e.Repo]
+	if !ok {
+		t = NewTracker()
+		d.trackers[e.Repo] = t
+	}
+	state := t.Apply(e)
+	if e.Type == AnalysisFinished {
+		d.done++
+	}
+
+	// Progress ticks are throttled; state changes are always drawn
+	if e.Type == DuplicationProgress && time.Since(d.lastDraw) < redrawInterval {
+		return
+	}
+	d.lastDraw = time.Now()
+
+	line := state.Describe()
+	if e.Repo != "" {
+		line = e.Repo + ": " + line
+	}
+	if len(d.trackers) > 1 {
+		line = fmt.Sprintf("[%d/%d repos done] %s", d.done, len(d.trackers), line)
+	}
+	fmt.Fprintf(d.w, "\r\033[K%s", line)
+	d.drawn = true
+}
+
+// Close clears the progress line so following output starts clean
+func (d *Display) Close() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.drawn {
+		fmt.Fprint(d.w, "\r\033[K")
+	}
+}
@@ -0,0 +1,111 @@
+package progress
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"sync"
+	"time"
+)
+
+// Event types
+const (
+	AnalysisStarted     = "analysis_started"
+	AnalysisFinished    = "analysis_finished"
+	Stage               = "stage"
+	DetectionStarted    = "detection_started"
+	DetectorStarted     = "detector_started"
+	DetectorFinished    = "detector_finished"
+	MetricsFetched      = "metrics_fetched"
+	DuplicationProgress = "duplication_progress"
+)
+
+// Event is one step of an analysis. Fields not relevant to the event type
+// are left empty.
+type Event struct {
+	Time       time.Time `json:"time"`
+	Type       string    `json:"type"`
+	Repo       string    `json:"repo,omitempty"`
+	Detector   string    `json:"detector,omitempty"`
+	Stage      string    `json:"stage,omitempty"`
+	Kind       string    `json:"kind,omitempty"` // Metric kind of metrics_fetched
+	Rows       int       `json:"rows,omitempty"` // Rows returned by a metrics query
+	Completed  int       `json:"completed,omitempty"`
+	Total      int       `json:"total,omitempty"`
+	Issues     int       `json:"issues,omitempty"`
+	DurationMs int64     `json:"duration_ms,omitempty"`
+	Error      string    `json:"error,omitempty"`
+}
+
+// Sink receives progress events. Implementations must be safe for
+// concurrent use, since detectors run in parallel.
+type Sink interface {
+	Emit(Event)
+}
+
+// SinkFunc adapts a function to a Sink
+type SinkFunc func(Event)
+
+// Emit calls f
+func (f SinkFunc) Emit(e Event) {
+	f(e)
+}
+
+type sinkKey struct{}
+type repoKey struct{}
+
+// WithSink returns a context whose analysis steps are reported to sink. A
+// sink already on ctx keeps receiving events.
+func WithSink(ctx context.Context, sink Sink) context.Context {
+	if existing, ok := ctx.Value(sinkKey{}).(Sink); ok {
+		sink = Multi(existing, sink)
+	}
+	return context.WithValue(ctx, sinkKey{}, sink)
+}
+
+// WithRepo returns a context whose events are attributed to repo
+func WithRepo(ctx context.Context, repo string) context.Context {
+	return context.WithValue(ctx, repoKey{}, repo)
+}
+
+// Emit reports an event to the sink on ctx, if there is one
+func Emit(ctx context.Context, e Event) {
+	sink, ok := ctx.Value(sinkKey{}).(Sink)
+	if !ok {
+		return
+	}
+	if e.Time.IsZero() {
+		e.Time = time.Now().UTC()
+	}
+	if e.Repo == "" {
+		e.Repo, _ = ctx.Value(repoKey{}).(string)
+	}
+	sink.Emit(e)
+}
+
+// Multi fans events out to several sinks
+func Multi(sinks ...Sink) Sink {
+	return SinkFunc(func(e Event) {
+		for _, s := range sinks {
+			s.Emit(e)
+		}
+	})
+}
+
+// NDJSONSink writes every event as one JSON line
+type NDJSONSink struct {
+	mu  sync.Mutex
+	enc *json.Encoder
+}
+
+// NewNDJSONSink creates a sink writing to w
+func NewNDJSONSink(w io.Writer) *NDJSONSink {
+	return &NDJSONSink{enc: json.NewEncoder(w)}
+}
+
+// Emit writes the event
+func (s *NDJSONSink) Emit(e Event) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_ = s.enc.Encode(e)
+}
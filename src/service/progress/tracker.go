@@ -0,0 +1,86 @@
+package progress
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// State is the progress of one analysis, folded from its events
+type State struct {
+	Stage            string
+	DetectorsDone    int
+	DetectorsTotal   int
+	Running          []string // Detectors currently running, sorted
+	DuplicationDone  int
+	DuplicationTotal int
+	Issues           int
+	Finished         bool
+}
+
+// Describe renders the state as a short status line
+func (s State) Describe() string {
+	if s.Finished {
+		return fmt.Sprintf("done, %d issues", s.Issues)
+	}
+	if len(s.Running) == 0 || s.Stage != "detecting" {
+		return s.Stage
+	}
+
+	parts := make([]string, 0, len(s.Running))
+	for _, name := range s.Running {
+		if name == "duplication" && s.DuplicationTotal > 0 {
+			name = fmt.Sprintf("duplication %d/%d", s.DuplicationDone, s.DuplicationTotal)
+		}
+		parts = append(parts, name)
+	}
+	return fmt.Sprintf("detectors %d/%d (running: %s)", s.DetectorsDone, s.DetectorsTotal, strings.Join(parts, ", "))
+}
+
+// Tracker folds the events of one analysis into its current state
+type Tracker struct {
+	mu      sync.Mutex
+	state   State
+	running map[string]bool
+}
+
+// NewTracker creates an empty tracker
+func NewTracker() *Tracker {
+	return &Tracker{running: make(map[string]bool)}
+}
+
+// Apply updates the state with an event and returns the new state
+func (t *Tracker) Apply(e Event) State {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	switch e.Type {
+	case AnalysisStarted:
+		t.state.Stage = "starting"
+	case Stage:
+		t.state.Stage = e.Stage
+	case DetectionStarted:
+		t.state.Stage = "detecting"
+		t.state.DetectorsTotal = e.Total
+	case DetectorStarted:
+		t.running[e.Detector] = true
+	case DetectorFinished:
+		delete(t.running, e.Detector)
+		t.state.DetectorsDone++
+	case DuplicationProgress:
+		t.state.DuplicationDone = e.Completed
+		t.state.DuplicationTotal = e.Total
+	case AnalysisFinished:
+		t.state.Finished = true
+		t.state.Issues = e.Issues
+		t.state.Stage = "done"
+	}
+
+	t.state.Running = t.state.Running[:0:0]
+	for name := range t.running {
+		t.state.Running = append(t.state.Running, name)
+	}
+	sort.Strings(t.state.Running)
+	return t.state
+}
@@ -0,0 +1,111 @@
+package remediation
+
+import (
+	"math"
+
+	"quality-bot/src/config"
+	"quality-bot/src/model"
+	"quality-bot/src/util"
+)
+
+// defaultMinutes is the base effort for rules missing from the catalog
+const defaultMinutes = 30
+
+// Estimator assigns remediation effort to issues using a SQALE-like cost
+// model: a base cost per rule, scaled by how far the issue's metric exceeds
+// its threshold
+type Estimator struct {
+	cfg config.RemediationConfig
+}
+
+// NewEstimator creates a new remediation estimator
+func NewEstimator(cfg config.RemediationConfig) *Estimator {
+	return &Estimator{cfg: cfg}
+}
+
+// Apply sets EffortMinutes on every issue
+func (e *Estimator) Apply(issues []model.DebtIssue) []model.DebtIssue {
+	for i := range issues {
+		issues[i].EffortMinutes = e.Estimate(issues[i])
+	}
+	return issues
+}
+
+// Estimate returns the remediation effort for a single issue in minutes
+func (e *Estimator) Estimate(issue model.DebtIssue) int {
+	rule, known := model.LookupRule(issue.RuleID())
+
+	base := defaultMinutes
+	if known {
+		base = rule.EffortMinutes
+	}
+	if minutes, ok := e.cfg.RuleMinutes[issue.RuleID()]; ok {
+		base = minutes
+	}
+
+	return int(math.Round(float64(base) * e.scale(issue, rule.EffortMetrics)))
+}
+
+// scale returns actual/threshold for the first effort metric present on the
+// issue, clamped to [1, MaxScale]. Issues without a threshold are not scaled.
+func (e *Estimator) scale(issue model.DebtIssue, metrics []string) float64 {
+	threshold, ok := toFloat(issue.Metrics["threshold"])
+	if !ok || threshold <= 0 {
+		return 1
+	}
+
+	for _, key := range metrics {
+		actual, ok := toFloat(issue.Metrics[key])
+		if !ok {
+			continue
+		}
+		ratio := max(actual/threshold, 1)
+		if e.cfg.MaxScale > 0 {
+			ratio = min(ratio, e.cfg.MaxScale)
+		}
+		return ratio
+	}
+
+	return 1
+}
+
+// Summarize totals the effort of the issues. Line counts of the analyzed
+// files give the development cost the debt ratio is measured against.
+func (e *Estimator) Summarize(issues []model.DebtIssue, files []model.FileMetrics) *model.RemediationSummary {
+	summary := &model.RemediationSummary{
+		ByCategory: make(map[model.Category]int),
+		BySeverity: make(map[model.Severity]int),
+		ByFile:     make(map[string]int),
+	}
+
+	for _, issue := range issues {
+		summary.TotalMinutes += issue.EffortMinutes
+		summary.ByCategory[issue.Category] += issue.EffortMinutes
+		summary.BySeverity[issue.Severity] += issue.EffortMinutes
+		summary.ByFile[issue.FilePath] += issue.EffortMinutes
+	}
+
+	for _, f := range files {
+		summary.LinesOfCode += f.LineCount
+	}
+	summary.DevelopmentCostMinutes = float64(summary.LinesOfCode) * e.cfg.MinutesPerLine
+	if summary.DevelopmentCostMinutes > 0 {
+		summary.DebtRatio = float64(summary.TotalMinutes) / summary.DevelopmentCostMinutes * 100
+	}
+
+	util.Debug("Remediation effort: %d minutes over %d lines (debt ratio %.2f%%)",
+		summary.TotalMinutes, summary.LinesOfCode, summary.DebtRatio)
+	return summary
+}
+
+func toFloat(v any) (float64, bool) {
+	switch n := v.(type) {
+	case int:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case float64:
+		return n, true
+	}
+	return 0, false
+}
@@ -0,0 +1,81 @@
+package report
+
+import (
+	"encoding/xml"
+	"sort"
+
+	"quality-bot/src/model"
+)
+
+// checkstyleResult is the root element of a Checkstyle XML report, as
+// consumed by Jenkins warnings-ng and most CI annotation plugins
+type checkstyleResult struct {
+	XMLName xml.Name         `xml:"checkstyle"`
+	Version string           `xml:"version,attr"`
+	Files   []checkstyleFile `xml:"file"`
+}
+
+type checkstyleFile struct {
+	Name   string            `xml:"name,attr"`
+	Errors []checkstyleError `xml:"error"`
+}
+
+type checkstyleError struct {
+	Line     int    `xml:"line,attr"`
+	Severity string `xml:"severity,attr"`
+	Message  string `xml:"message,attr"`
+	Source   string `xml:"source,attr"`
+}
+
+// generateCheckstyle renders one file element per affected file, ordered by
+// path, with the issues in each file ordered by line
+func (g *Generator) generateCheckstyle(report *model.AnalysisReport) (string, error) {
+	byFile := make(map[string][]model.DebtIssue)
+	for _, issue := range report.Issues {
+		byFile[issue.FilePath] = append(byFile[issue.FilePath], issue)
+	}
+
+	paths := make([]string, 0, len(byFile))
+	for path := range byFile {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	root := checkstyleResult{Version: "4.3"}
+	for _, path := range paths {
+		issues := byFile[path]
+		sort.SliceStable(issues, func(i, j int) bool {
+			return issues[i].StartLine < issues[j].StartLine
+		})
+
+		file := checkstyleFile{Name: path}
+		for _, issue := range issues {
+			file.Errors = append(file.Errors, checkstyleError{
+				Line:     max(issue.StartLine, 1),
+				Severity: checkstyleSeverity(issue.Severity),
+				Message:  issue.Description,
+				Source:   issue.RuleID(),
+			})
+		}
+		root.Files = append(root.Files, file)
+	}
+
+	data, err := xml.MarshalIndent(root, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return xml.Header + string(data) + "\n", nil
+}
+
+// checkstyleSeverity maps severities onto the Checkstyle levels
+// (error, warning, info)
+func checkstyleSeverity(s model.Severity) string {
+	switch s {
+	case model.SeverityCritical, model.SeverityHigh:
+		return "error"
+	case model.SeverityMedium:
+		return "warning"
+	default:
+		return "info"
+	}
+}
@@ -0,0 +1,116 @@
+package report
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
+	"quality-bot/src/model"
+)
+
+// codeClimateIssue is an issue in the Code Climate JSON format consumed by
+// GitLab Code Quality
+type codeClimateIssue struct {
+	Type        string              `json:"type"`
+	CheckName   string              `json:"check_name"`
+	Description string              `json:"description"`
+	Categories  []string            `json:"categories"`
+	Fingerprint string              `json:"fingerprint"`
+	Severity    string              `json:"severity"`
+	Location    codeClimateLocation `json:"location"`
+}
+
+type codeClimateLocation struct {
+	Path  string           `json:"path"`
+	Lines codeClimateLines `json:"lines"`
+}
+
+type codeClimateLines struct {
+	Begin int `json:"begin"`
+	End   int `json:"end,omitempty"`
+}
+
+func (g *Generator) generateCodeClimate(report *model.AnalysisReport) (string, error) {
+	issues := make([]codeClimateIssue, 0, len(report.Issues))
+	fingerprints := codeClimateFingerprints(report.Issues)
+
+	for i, issue := range report.Issues {
+		issues = append(issues, codeClimateIssue{
+			Type:        "issue",
+			CheckName:   issue.RuleID(),
+			Description: issue.Description,
+			Categories:  []string{codeClimateCategory(issue.Category)},
+			Fingerprint: fingerprints[i],
+			Severity:    codeClimateSeverity(issue.Severity),
+			Location: codeClimateLocation{
+				Path: issue.FilePath,
+				Lines: codeClimateLines{
+					Begin: max(issue.StartLine, 1),
+					End:   issue.EndLine,
+				},
+			},
+		})
+	}
+
+	data, err := json.MarshalIndent(issues, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// codeClimateSeverity maps severities onto the Code Climate scale
+// (info, minor, major, critical, blocker). "info" is not used since every
+// reported issue crossed a threshold.
+func codeClimateSeverity(s model.Severity) string {
+	switch s {
+	case model.SeverityCritical:
+		return "blocker"
+	case model.SeverityHigh:
+		return "critical"
+	case model.SeverityMedium:
+		return "major"
+	default:
+		return "minor"
+	}
+}
+
+func codeClimateCategory(c model.Category) string {
+	switch c {
+	case model.CategoryComplexity, model.CategorySize:
+		return "Complexity"
+	case model.CategoryDuplication:
+		return "Duplication"
+	default:
+		return "Clarity"
+	}
+}
+
+// codeClimateFingerprints returns the fingerprint of every issue. GitLab
+// merges issues with equal fingerprints, so issues sharing the line-independent
+// fingerprint (e.g. two overloads with the same name) are told apart by their
+// lines, and by their order if even those are equal.
+func codeClimateFingerprints(issues []model.DebtIssue) []string {
+	fingerprints := make([]string, len(issues))
+	count := make(map[string]int, len(issues))
+	for i, issue := range issues {
+		fingerprints[i] = issue.Fingerprint()
+		count[fingerprints[i]]++
+	}
+
+	seen := make(map[string]int)
+	for i, issue := range issues {
+		if count[fingerprints[i]] == 1 {
+			continue
+		}
+		key := fmt.Sprintf("%s:%d-%d", fingerprints[i], issue.StartLine, issue.EndLine)
+		seen[key]++
+		if n := seen[key]; n > 1 {
+			key += fmt.Sprintf("#%d", n)
+		}
+		sum := sha256.Sum256([]byte(key))
+		fingerprints[i] = hex.EncodeToString(sum[:16])
+	}
+	return fingerprints
+}
@@ -0,0 +1,140 @@
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"quality-bot/src/model"
+	"quality-bot/src/service/export"
+	"quality-bot/src/util"
+)
+
+// GenerateExplanation renders the explanation of a single entity in the
+// specified format (markdown or json)
+func (g *Generator) GenerateExplanation(e *model.Explanation, format string) (string, error) {
+	util.Debug("Generating explanation in %s format (%d checks)", format, len(e.Checks))
+	switch format {
+	case "json":
+		data, err := json.MarshalIndent(e, "", "  ")
+		if err != nil {
+			return "", err
+		}
+		return string(data), nil
+	case "markdown", "md":
+		return g.generateExplanationMarkdown(e)
+	default:
+		return "", fmt.Errorf("unsupported explain format: %s", format)
+	}
+}
+
+func (g *Generator) generateExplanationMarkdown(e *model.Explanation) (string, error) {
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf("# Explanation: %s `%s`\n\n", e.EntityType, e.EntityName))
+	sb.WriteString(fmt.Sprintf("**Repository:** %s\n", e.RepoName))
+	sb.WriteString(fmt.Sprintf("**File:** %s\n", e.FilePath))
+	if e.Language != "" {
+		sb.WriteString(fmt.Sprintf("**Language:** %s\n", e.Language))
+	}
+	if e.Profile != "" {
+		sb.WriteString(fmt.Sprintf("**Profile:** %s\n", e.Profile))
+	}
+	sb.WriteString("\n")
+
+	sb.WriteString("## Metrics\n\n")
+	sb.WriteString("| Metric | Value |\n")
+	sb.WriteString("|--------|-------|\n")
+	var table export.Table
+	switch {
+	case e.Function != nil:
+		table = export.FromStructs([]model.FunctionMetrics{*e.Function})
+	case e.Class != nil:
+		table = export.FromStructs([]model.ClassMetrics{*e.Class})
+	case e.File != nil:
+		table = export.FromStructs([]model.FileMetrics{*e.File})
+	}
+	for _, row := range table.Rows {
+		for _, col := range table.Header {
+			sb.WriteString(fmt.Sprintf("| %s | %v |\n", col, row[col]))
+		}
+	}
+	sb.WriteString("\n")
+
+	sb.WriteString("## Threshold Checks\n\n")
+	if len(e.Checks) == 0 {
+		sb.WriteString("No detector checks this kind of entity.\n\n")
+	} else {
+		sb.WriteString("An issue is raised when the value exceeds the threshold.\n\n")
+		sb.WriteString("| Detector | Rule | Metric | Value | Threshold | Result | Note |\n")
+		sb.WriteString("|----------|------|--------|-------|-----------|--------|------|\n")
+		for _, c := range e.Checks {
+			sb.WriteString(fmt.Sprintf("| %s | %s | %s | %s | %s | %s | %s |\n",
+				c.Detector, c.Rule, c.Metric, formatNumber(c.Value), formatNumber(c.Threshold),
+				checkResult(c), c.Note))
+		}
+		sb.WriteString("\n")
+	}
+
+	sb.WriteString("## Exclusions\n\n")
+	if len(e.Exclusions) == 0 {
+		sb.WriteString("No exclusion rule matches.\n\n")
+	} else {
+		sb.WriteString("Detectors skip this entity; the checks above show what would apply otherwise.\n\n")
+		for _, rule := range e.Exclusions {
+			sb.WriteString(fmt.Sprintf("- `%s`\n", rule))
+		}
+		sb.WriteString("\n")
+	}
+
+	sb.WriteString("## Issues\n\n")
+	if len(e.Issues) == 0 {
+		sb.WriteString("No issues raised.\n\n")
+	} else {
+		sb.WriteString("As raised by the detectors, before inline suppressions and owner filters.\n\n")
+		for _, issue := range e.Issues {
+			sb.WriteString(fmt.Sprintf("- **%s** %s/%s (lines %d-%d): %s\n",
+				strings.ToUpper(string(issue.Severity)), issue.Category, issue.Subcategory,
+				issue.StartLine, issue.EndLine, issue.Description))
+		}
+		sb.WriteString("\n")
+	}
+
+	sb.WriteString("## Raw CodeAPI Rows\n\n")
+	for _, raw := range e.RawRows {
+		sb.WriteString(fmt.Sprintf("### %s query (%d rows)\n\n", raw.Kind, len(raw.Rows)))
+		sb.WriteString("```cypher\n" + raw.Query + "\n```\n\n")
+		if len(raw.Rows) == 0 {
+			continue
+		}
+		data, err := json.MarshalIndent(raw.Rows, "", "  ")
+		if err != nil {
+			return "", err
+		}
+		sb.WriteString("```json\n" + string(data) + "\n```\n\n")
+	}
+
+	return sb.String(), nil
+}
+
+func checkResult(c model.ThresholdCheck) string {
+	result := "ok"
+	if c.Exceeded {
+		result = "**exceeded**"
+		if c.Severity != "" {
+			result += " (" + string(c.Severity) + ")"
+		}
+	}
+	if c.Disabled {
+		result += ", detector disabled"
+	}
+	if c.RuleOff {
+		result += ", rule disabled for language"
+	}
+	return result
+}
+
+func formatNumber(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
@@ -3,22 +3,34 @@ package report
 import (
 	"encoding/json"
 	"fmt"
+	"html"
 	"sort"
 	"strings"
+	"time"
 
 	"quality-bot/src/config"
 	"quality-bot/src/model"
 	"quality-bot/src/util"
 )
 
+// severityOrder lists severities from most to least severe, as rendered in reports
+var severityOrder = []model.Severity{model.SeverityCritical, model.SeverityHigh, model.SeverityMedium, model.SeverityLow}
+
+// categoryOrder lists the categories rendered in reports
+var categoryOrder = []model.Category{model.CategoryComplexity, model.CategorySize, model.CategoryCoupling, model.CategoryDuplication}
+
 // Generator generates reports in various formats
 type Generator struct {
-	cfg config.OutputConfig
+	cfg     config.OutputConfig
+	agent   config.AgentConfig
+	scoring config.ScoringConfig
 }
 
-// NewGenerator creates a new report generator
-func NewGenerator(cfg config.OutputConfig) *Generator {
-	return &Generator{cfg: cfg}
+// NewGenerator creates a new report generator. Besides the output settings it
+// uses the agent metadata, which identifies the tool in SARIF, and the scoring
+// settings the reports explain.
+func NewGenerator(cfg *config.Config) *Generator {
+	return &Generator{cfg: cfg.Output, agent: cfg.Agent, scoring: cfg.Scoring}
 }
 
 // Generate generates a report in the specified format
@@ -31,6 +43,20 @@ func (g *Generator) Generate(report *model.AnalysisReport, format string) (strin
 		return g.generateMarkdown(report)
 	case "sarif":
 		return g.generateSARIF(report)
+	case "pr-comment":
+		return g.generatePRComment(report)
+	case "codeclimate":
+		return g.generateCodeClimate(report)
+	case "junit":
+		return g.generateJUnit(report)
+	case "html":
+		return g.generateHTML(report)
+	case "checkstyle":
+		return g.generateCheckstyle(report)
+	case "sonarqube":
+		return g.generateSonarQube(report)
+	case "csv", "jsonl":
+		return g.issueTable(report).Encode(format)
 	default:
 		util.Warn("Unsupported report format requested: %s", format)
 		return "", fmt.Errorf("unsupported format: %s", format)
@@ -51,33 +77,135 @@ func (g *Generator) generateMarkdown(report *model.AnalysisReport) (string, erro
 	// Header
 	sb.WriteString("# Technical Debt Analysis Report\n\n")
 	sb.WriteString(fmt.Sprintf("**Repository:** %s\n", report.RepoName))
-	sb.WriteString(fmt.Sprintf("**Generated:** %s\n\n", report.GeneratedAt.Format("2006-01-02 15:04:05 UTC")))
+	sb.WriteString(fmt.Sprintf("**Generated:** %s\n", report.GeneratedAt.Format("2006-01-02 15:04:05 UTC")))
+	if report.Scope != nil {
+		sb.WriteString(fmt.Sprintf("**Scope:** %s\n", scopeDescription(report.Scope)))
+	}
+	sb.WriteString("\n")
 
 	// Summary
 	sb.WriteString("## Summary\n\n")
 	sb.WriteString(fmt.Sprintf("- **Total Issues:** %d\n", report.Summary.TotalIssues))
-	sb.WriteString(fmt.Sprintf("- **Debt Score:** %.1f/100\n\n", report.Summary.DebtScore))
+	if report.Summary.SuppressedCount > 0 {
+		sb.WriteString(fmt.Sprintf("- **Suppressed Issues:** %d\n", report.Summary.SuppressedCount))
+	}
+	sb.WriteString(fmt.Sprintf("- **Debt Score:** %.1f per 1,000 lines, grade **%s** (%d lines analyzed)\n",
+		report.Summary.DebtScore, report.Summary.DebtGrade, report.Summary.LinesOfCode))
+	if r := report.Summary.Remediation; r != nil {
+		sb.WriteString(fmt.Sprintf("- **Remediation Effort:** %s\n", formatEffort(r.TotalMinutes)))
+		if r.LinesOfCode > 0 {
+			sb.WriteString(fmt.Sprintf("- **Debt Ratio:** %.2f%% (of an estimated %s to develop %d lines)\n",
+				r.DebtRatio, formatEffort(int(r.DevelopmentCostMinutes)), r.LinesOfCode))
+		}
+	}
+	sb.WriteString("\n")
+
+	// Quality gate
+	if report.QualityGate != nil {
+		verdict := "PASSED"
+		if !report.QualityGate.Passed {
+			verdict = "FAILED"
+		}
+		sb.WriteString(fmt.Sprintf("### Quality Gate: %s\n\n", verdict))
+		sb.WriteString("| Condition | Actual | Max | Status |\n")
+		sb.WriteString("|-----------|--------|-----|--------|\n")
+		for _, c := range report.QualityGate.Conditions {
+			status := "pass"
+			if !c.Passed {
+				status = "fail"
+			}
+			sb.WriteString(fmt.Sprintf("| %s | %g | %g | %s |\n", c.Name, c.Actual, c.Threshold, status))
+		}
+		sb.WriteString("\n")
+	}
 
 	// By Severity
+	remediation := report.Summary.Remediation
 	sb.WriteString("### Issues by Severity\n\n")
-	sb.WriteString("| Severity | Count |\n")
-	sb.WriteString("|----------|-------|\n")
-	for _, sev := range []model.Severity{model.SeverityCritical, model.SeverityHigh, model.SeverityMedium, model.SeverityLow} {
+	if remediation != nil {
+		sb.WriteString("| Severity | Count | Effort |\n")
+		sb.WriteString("|----------|-------|--------|\n")
+	} else {
+		sb.WriteString("| Severity | Count |\n")
+		sb.WriteString("|----------|-------|\n")
+	}
+	for _, sev := range severityOrder {
 		count := report.Summary.BySeverity[sev]
-		sb.WriteString(fmt.Sprintf("| %s | %d |\n", sev, count))
+		if remediation != nil {
+			sb.WriteString(fmt.Sprintf("| %s | %d | %s |\n", sev, count, formatEffort(remediation.BySeverity[sev])))
+		} else {
+			sb.WriteString(fmt.Sprintf("| %s | %d |\n", sev, count))
+		}
 	}
 	sb.WriteString("\n")
 
 	// By Category
 	sb.WriteString("### Issues by Category\n\n")
-	sb.WriteString("| Category | Count |\n")
-	sb.WriteString("|----------|-------|\n")
-	for _, cat := range []model.Category{model.CategoryComplexity, model.CategorySize, model.CategoryCoupling, model.CategoryDuplication} {
+	if remediation != nil {
+		sb.WriteString("| Category | Count | Effort |\n")
+		sb.WriteString("|----------|-------|--------|\n")
+	} else {
+		sb.WriteString("| Category | Count |\n")
+		sb.WriteString("|----------|-------|\n")
+	}
+	for _, cat := range categoryOrder {
 		count := report.Summary.ByCategory[cat]
-		sb.WriteString(fmt.Sprintf("| %s | %d |\n", cat, count))
+		if remediation != nil {
+			sb.WriteString(fmt.Sprintf("| %s | %d | %s |\n", cat, count, formatEffort(remediation.ByCategory[cat])))
+		} else {
+			sb.WriteString(fmt.Sprintf("| %s | %d |\n", cat, count))
+		}
 	}
 	sb.WriteString("\n")
 
+	// By Owner
+	if len(report.Summary.ByOwner) > 0 {
+		owners := make([]string, 0, len(report.Summary.ByOwner))
+		for o := range report.Summary.ByOwner {
+			owners = append(owners, o)
+		}
+		sort.Slice(owners, func(i, j int) bool {
+			ci, cj := report.Summary.ByOwner[owners[i]], report.Summary.ByOwner[owners[j]]
+			if ci != cj {
+				return ci > cj
+			}
+			return owners[i] < owners[j]
+		})
+
+		sb.WriteString("### Issues by Owner\n\n")
+		sb.WriteString("| Owner | Count |\n")
+		sb.WriteString("|-------|-------|\n")
+		for _, o := range owners {
+			sb.WriteString(fmt.Sprintf("| %s | %d |\n", o, report.Summary.ByOwner[o]))
+		}
+		sb.WriteString("\n")
+	}
+
+	// Complex files that change often
+	if len(report.Summary.ChurnHotspots) > 0 {
+		sb.WriteString("### Churn Hotspots\n\n")
+		sb.WriteString("Files ranked by total complexity x commits: complex code that changes often.\n\n")
+		sb.WriteString("| File | Score | Complexity | Commits | Recent Churn (lines) | Authors | Issues |\n")
+		sb.WriteString("|------|-------|------------|---------|----------------------|---------|--------|\n")
+		for _, h := range report.Summary.ChurnHotspots {
+			sb.WriteString(fmt.Sprintf("| %s | %d | %d | %d | %d | %d | %d |\n",
+				h.FilePath, h.Score, h.Complexity, h.Commits, h.RecentChurn, h.Authors, h.IssueCount))
+		}
+		sb.WriteString("\n")
+	}
+
+	// Worst scored directories and files
+	g.writeAreaScores(&sb, "Directory Scores", "Directory", report.Summary.DirectoryScores)
+	g.writeAreaScores(&sb, "File Scores", "File", report.Summary.FileScores)
+
+	// Subtree totals for teams owning a directory
+	if root := report.Summary.Directories; root != nil && root.TotalIssues > 0 {
+		sb.WriteString("### Directory Rollup\n\n")
+		sb.WriteString("Totals include all subdirectories; expand a directory to see its children.\n\n")
+		writeRollup(&sb, root, report.RepoName, true)
+		sb.WriteString("\n")
+	}
+
 	// Hotspots
 	if len(report.Summary.HotspotFiles) > 0 {
 		sb.WriteString("### Hotspot Files\n\n")
@@ -100,17 +228,21 @@ func (g *Generator) generateMarkdown(report *model.AnalysisReport) (string, erro
 	// Sort issues by severity within each category (critical first)
 	for cat := range issuesByCategory {
 		sort.Slice(issuesByCategory[cat], func(i, j int) bool {
-			return severityPriority(issuesByCategory[cat][i].Severity) < severityPriority(issuesByCategory[cat][j].Severity)
+			return model.SeverityPriority(issuesByCategory[cat][i].Severity) < model.SeverityPriority(issuesByCategory[cat][j].Severity)
 		})
 	}
 
-	for _, cat := range []model.Category{model.CategoryComplexity, model.CategorySize, model.CategoryCoupling, model.CategoryDuplication} {
+	for _, cat := range categoryOrder {
 		issues := issuesByCategory[cat]
 		if len(issues) == 0 {
 			continue
 		}
 
-		sb.WriteString(fmt.Sprintf("### %s (%d issues)\n\n", strings.Title(string(cat)), len(issues)))
+		label := fmt.Sprintf("%d issues", len(issues))
+		if total := report.Summary.ByCategory[cat]; total > len(issues) {
+			label = fmt.Sprintf("%d issues, %d listed", total, len(issues))
+		}
+		sb.WriteString(fmt.Sprintf("### %s (%s)\n\n", strings.Title(string(cat)), label))
 
 		for _, issue := range issues {
 			sb.WriteString(fmt.Sprintf("#### %s `%s`\n\n", severityEmoji(issue.Severity), issue.EntityName))
@@ -118,6 +250,15 @@ func (g *Generator) generateMarkdown(report *model.AnalysisReport) (string, erro
 			sb.WriteString(fmt.Sprintf("- **Type:** %s\n", issue.Subcategory))
 			sb.WriteString(fmt.Sprintf("- **Severity:** %s\n", issue.Severity))
 			sb.WriteString(fmt.Sprintf("- **Description:** %s\n", issue.Description))
+			if issue.EffortMinutes > 0 {
+				sb.WriteString(fmt.Sprintf("- **Effort:** %s\n", formatEffort(issue.EffortMinutes)))
+			}
+			if len(issue.Owners) > 0 {
+				sb.WriteString(fmt.Sprintf("- **Owners:** %s\n", strings.Join(issue.Owners, " ")))
+			}
+			if issue.Profile != "" {
+				sb.WriteString(fmt.Sprintf("- **Profile:** %s\n", issue.Profile))
+			}
 
 			if g.cfg.IncludeSuggestions && issue.Suggestion != "" {
 				sb.WriteString(fmt.Sprintf("- **Suggestion:** %s\n", issue.Suggestion))
@@ -140,10 +281,38 @@ func (g *Generator) generateMarkdown(report *model.AnalysisReport) (string, erro
 		}
 	}
 
+	// Suppressed issues are listed for auditing
+	if len(report.Suppressed) > 0 {
+		sb.WriteString(fmt.Sprintf("## Suppressed Issues (%d)\n\n", len(report.Suppressed)))
+		sb.WriteString("| Rule | Entity | Location | Reason |\n")
+		sb.WriteString("|------|--------|----------|--------|\n")
+		for _, s := range report.Suppressed {
+			reason := s.Reason
+			if reason == "" {
+				reason = "_no reason given_"
+			}
+			sb.WriteString(fmt.Sprintf("| %s | `%s` | `%s:%d` | %s |\n",
+				s.Issue.RuleID(), s.Issue.EntityName, s.Issue.FilePath, s.Line, reason))
+		}
+		sb.WriteString("\n")
+	}
+
+	g.writeScoreNote(&sb)
+
 	return sb.String(), nil
 }
 
+// sarifSourceRoot is the base ID all SARIF locations are relative to
+const sarifSourceRoot = "SRCROOT"
+
 func (g *Generator) generateSARIF(report *model.AnalysisReport) (string, error) {
+	rules, ruleIndex := g.buildSARIFRules(report.Issues)
+
+	startedAt := report.StartedAt
+	if startedAt.IsZero() {
+		startedAt = report.GeneratedAt
+	}
+
 	sarif := map[string]any{
 		"$schema": "https://raw.githubusercontent.com/oasis-tcs/sarif-spec/master/Schemata/sarif-schema-2.1.0.json",
 		"version": "2.1.0",
@@ -151,13 +320,26 @@ func (g *Generator) generateSARIF(report *model.AnalysisReport) (string, error)
 			{
 				"tool": map[string]any{
 					"driver": map[string]any{
-						"name":           "quality-bot",
-						"version":        "1.0.0",
-						"informationUri": "https://github.com/example/quality-bot",
-						"rules":          g.buildSARIFRules(report.Issues),
+						"name":            g.agent.Name,
+						"version":         g.agent.Version,
+						"semanticVersion": g.agent.Version,
+						"informationUri":  "https://github.com/armchr/quality-bot",
+						"rules":           rules,
 					},
 				},
-				"results": g.buildSARIFResults(report.Issues),
+				"invocations": []map[string]any{
+					{
+						"executionSuccessful": true,
+						"startTimeUtc":        startedAt.UTC().Format(time.RFC3339),
+						"endTimeUtc":          report.GeneratedAt.UTC().Format(time.RFC3339),
+					},
+				},
+				"originalUriBaseIds": map[string]any{
+					sarifSourceRoot: map[string]any{
+						"description": map[string]any{"text": "Root of repository " + report.RepoName},
+					},
+				},
+				"results": g.buildSARIFResults(report.Issues, ruleIndex),
 			},
 		},
 	}
@@ -169,53 +351,110 @@ func (g *Generator) generateSARIF(report *model.AnalysisReport) (string, error)
 	return string(data), nil
 }
 
-func (g *Generator) buildSARIFRules(issues []model.DebtIssue) []map[string]any {
-	ruleMap := make(map[string]bool)
+// buildSARIFRules emits every catalog rule followed by any rule found in the
+// issues that is not in the catalog. It returns the rule index by ID so
+// results can reference their rule.
+func (g *Generator) buildSARIFRules(issues []model.DebtIssue) ([]map[string]any, map[string]int) {
 	var rules []map[string]any
+	ruleIndex := make(map[string]int)
+
+	for _, r := range model.Rules {
+		ruleIndex[r.ID] = len(rules)
+		rules = append(rules, sarifRule(r))
+	}
 
 	for _, issue := range issues {
-		ruleID := string(issue.Category) + "/" + issue.Subcategory
-		if ruleMap[ruleID] {
+		ruleID := issue.RuleID()
+		if _, ok := ruleIndex[ruleID]; ok {
 			continue
 		}
-		ruleMap[ruleID] = true
+		ruleIndex[ruleID] = len(rules)
+		rules = append(rules, sarifRule(model.Rule{
+			ID:               ruleID,
+			Name:             issue.Subcategory,
+			ShortDescription: issue.Subcategory,
+			DefaultSeverity:  model.SeverityMedium,
+			Tags:             []string{"maintainability", string(issue.Category)},
+		}))
+	}
 
-		rules = append(rules, map[string]any{
-			"id":   ruleID,
-			"name": issue.Subcategory,
-			"shortDescription": map[string]any{
-				"text": issue.Description,
-			},
-			"defaultConfiguration": map[string]any{
-				"level": sarifLevel(issue.Severity),
-			},
-		})
+	return rules, ruleIndex
+}
+
+func sarifRule(r model.Rule) map[string]any {
+	rule := map[string]any{
+		"id":   r.ID,
+		"name": r.Name,
+		"shortDescription": map[string]any{
+			"text": r.ShortDescription,
+		},
+		"defaultConfiguration": map[string]any{
+			"level": sarifLevel(r.DefaultSeverity),
+		},
+		"properties": map[string]any{
+			"tags": r.Tags,
+		},
 	}
 
-	return rules
+	if r.FullDescription != "" {
+		rule["fullDescription"] = map[string]any{"text": r.FullDescription}
+	}
+	if r.Help != "" {
+		rule["help"] = map[string]any{
+			"text":     r.Help,
+			"markdown": fmt.Sprintf("**%s**\n\n%s\n\n%s", r.ShortDescription, r.FullDescription, r.Help),
+		}
+	}
+
+	return rule
 }
 
-func (g *Generator) buildSARIFResults(issues []model.DebtIssue) []map[string]any {
-	var results []map[string]any
+func (g *Generator) buildSARIFResults(issues []model.DebtIssue, ruleIndex map[string]int) []map[string]any {
+	results := make([]map[string]any, 0, len(issues))
 
 	for _, issue := range issues {
+		physical := map[string]any{
+			"artifactLocation": map[string]any{
+				"uri":       issue.FilePath,
+				"uriBaseId": sarifSourceRoot,
+			},
+		}
+		// Regions must start at line 1 or later; file-level issues have none
+		if issue.StartLine > 0 {
+			physical["region"] = map[string]any{
+				"startLine": issue.StartLine,
+				"endLine":   max(issue.EndLine, issue.StartLine),
+			}
+		}
+
+		properties := map[string]any{
+			"category":   issue.Category,
+			"severity":   issue.Severity,
+			"entityName": issue.EntityName,
+			"entityType": issue.EntityType,
+		}
+		if len(issue.Owners) > 0 {
+			properties["owners"] = issue.Owners
+		}
+		if issue.Profile != "" {
+			properties["profile"] = issue.Profile
+		}
+		if g.cfg.IncludeMetrics && len(issue.Metrics) > 0 {
+			properties["metrics"] = issue.Metrics
+		}
+
 		result := map[string]any{
-			"ruleId":  string(issue.Category) + "/" + issue.Subcategory,
-			"level":   sarifLevel(issue.Severity),
-			"message": map[string]any{"text": issue.Description},
+			"ruleId":    issue.RuleID(),
+			"ruleIndex": ruleIndex[issue.RuleID()],
+			"level":     sarifLevel(issue.Severity),
+			"message":   map[string]any{"text": issue.Description},
 			"locations": []map[string]any{
-				{
-					"physicalLocation": map[string]any{
-						"artifactLocation": map[string]any{
-							"uri": issue.FilePath,
-						},
-						"region": map[string]any{
-							"startLine": issue.StartLine,
-							"endLine":   issue.EndLine,
-						},
-					},
-				},
+				{"physicalLocation": physical},
 			},
+			"partialFingerprints": map[string]any{
+				"qualityBotFingerprint/v1": issue.Fingerprint(),
+			},
+			"properties": properties,
 		}
 
 		if issue.Suggestion != "" {
@@ -232,6 +471,122 @@ func (g *Generator) buildSARIFResults(issues []model.DebtIssue) []map[string]any
 	return results
 }
 
+func scopeDescription(scope *model.AnalysisScope) string {
+	source := "changed files list " + scope.ChangedFiles
+	if scope.ChangedSince != "" {
+		source = "changes since " + scope.ChangedSince
+	}
+	return fmt.Sprintf("%s (%d files, %d issues on unchanged code omitted)", source, scope.FileCount, scope.OutOfScope)
+}
+
+// writeRollup renders a directory and its subdirectories with issues as
+// nested collapsible HTML blocks, which GitHub and GitLab render in Markdown
+func writeRollup(sb *strings.Builder, dir *model.DirectoryRollup, label string, open bool) {
+	if label == "" {
+		label = dir.Path
+	}
+
+	var severities []string
+	for _, sev := range severityOrder {
+		if n := dir.BySeverity[sev]; n > 0 {
+			severities = append(severities, fmt.Sprintf("%d %s", n, sev))
+		}
+	}
+
+	openAttr := ""
+	if open {
+		openAttr = " open"
+	}
+	sb.WriteString(fmt.Sprintf("<details%s><summary><code>%s</code>: %d issues (%s), score %.1f (%s), %d lines</summary>\n",
+		openAttr, html.EscapeString(label), dir.TotalIssues, strings.Join(severities, ", "), dir.DebtScore, dir.DebtGrade, dir.LinesOfCode))
+	sb.WriteString("<blockquote>\n")
+
+	var categories []string
+	for _, cat := range categoryOrder {
+		if n := dir.ByCategory[cat]; n > 0 {
+			categories = append(categories, fmt.Sprintf("%s %d", cat, n))
+		}
+	}
+	sb.WriteString("Categories: " + strings.Join(categories, ", ") + "<br>\n")
+	if w := dir.WorstEntity; w != nil {
+		sb.WriteString(fmt.Sprintf("Worst: <code>%s</code> (%s %s, %s) in <code>%s</code>\n",
+			html.EscapeString(w.Name), w.Severity, w.Rule, w.Type, html.EscapeString(w.FilePath)))
+	}
+
+	for _, child := range dir.Children {
+		if child.TotalIssues > 0 {
+			writeRollup(sb, child, "", false)
+		}
+	}
+
+	sb.WriteString("</blockquote>\n</details>\n")
+}
+
+// writeAreaScores renders the HotspotsTopN worst areas as a table
+func (g *Generator) writeAreaScores(sb *strings.Builder, title, label string, scores []model.AreaScore) {
+	if len(scores) == 0 {
+		return
+	}
+	if g.cfg.HotspotsTopN > 0 && len(scores) > g.cfg.HotspotsTopN {
+		scores = scores[:g.cfg.HotspotsTopN]
+	}
+
+	sb.WriteString(fmt.Sprintf("### %s\n\n", title))
+	sb.WriteString(fmt.Sprintf("| %s | Grade | Score | Lines |\n", label))
+	sb.WriteString("|------|-------|-------|-------|\n")
+	for _, s := range scores {
+		sb.WriteString(fmt.Sprintf("| %s | %s | %.1f | %d |\n", s.Path, s.Grade, s.Score, s.Lines))
+	}
+	sb.WriteString("\n")
+}
+
+// writeScoreNote adds a section explaining how the debt score is computed
+func (g *Generator) writeScoreNote(sb *strings.Builder) {
+	sb.WriteString("## About the Debt Score\n\n")
+	sb.WriteString(g.scoreNote() + "\n\n")
+}
+
+// scoreNote explains the debt score with the configured weights and bands
+func (g *Generator) scoreNote() string {
+	var weights []string
+	for _, sev := range severityOrder {
+		weights = append(weights, fmt.Sprintf("%s=%g", sev, g.scoring.SeverityWeights[string(sev)]))
+	}
+	return fmt.Sprintf("The debt score is the sum of issue weights by severity (%s) per 1,000 lines of analyzed code, "+
+		"so repositories and directories of different sizes can be compared; areas under %d lines count as %d lines. "+
+		"Grades: A <= %g, B <= %g, C <= %g, D <= %g, E above.",
+		strings.Join(weights, ", "), g.scoring.MinLines, g.scoring.MinLines,
+		g.scoring.Grades.A, g.scoring.Grades.B, g.scoring.Grades.C, g.scoring.Grades.D)
+}
+
+// scoreLabel renders a debt score with its grade, if known
+func scoreLabel(score float64, grade string) string {
+	if grade == "" {
+		return fmt.Sprintf("%.1f", score)
+	}
+	return fmt.Sprintf("%.1f (%s)", score, grade)
+}
+
+// formatEffort renders minutes as working days (8h), hours and minutes
+func formatEffort(minutes int) string {
+	if minutes <= 0 {
+		return "-"
+	}
+	days, hours, mins := minutes/480, minutes%480/60, minutes%60
+	switch {
+	case days > 0 && hours > 0:
+		return fmt.Sprintf("%dd %dh", days, hours)
+	case days > 0:
+		return fmt.Sprintf("%dd", days)
+	case hours > 0 && mins > 0:
+		return fmt.Sprintf("%dh %dmin", hours, mins)
+	case hours > 0:
+		return fmt.Sprintf("%dh", hours)
+	default:
+		return fmt.Sprintf("%dmin", mins)
+	}
+}
+
 func severityEmoji(s model.Severity) string {
 	switch s {
 	case model.SeverityCritical:
@@ -255,17 +610,3 @@ func sarifLevel(s model.Severity) string {
 		return "note"
 	}
 }
-
-// severityPriority returns a numeric priority for sorting (lower = more critical)
-func severityPriority(s model.Severity) int {
-	switch s {
-	case model.SeverityCritical:
-		return 0
-	case model.SeverityHigh:
-		return 1
-	case model.SeverityMedium:
-		return 2
-	default:
-		return 3
-	}
-}
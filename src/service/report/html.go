@@ -0,0 +1,102 @@
+package report
+
+import (
+	_ "embed"
+	"encoding/json"
+	"html/template"
+	"path"
+	"strings"
+
+	"quality-bot/src/model"
+)
+
+//go:embed templates/report.html.tmpl
+var htmlTemplateText string
+
+var htmlTemplate = template.Must(template.New("report").Parse(htmlTemplateText))
+
+// htmlIssue is an issue with the derived fields the HTML report filters on
+type htmlIssue struct {
+	model.DebtIssue
+	Rule      string `json:"rule"`
+	Detector  string `json:"detector"`
+	Directory string `json:"directory"`
+}
+
+// htmlData is embedded into the page as JSON and rendered client-side
+type htmlData struct {
+	RepoName           string              `json:"repo_name"`
+	GeneratedAt        string              `json:"generated_at"`
+	Summary            model.ReportSummary `json:"summary"`
+	Issues             []htmlIssue         `json:"issues"`
+	Suppressed         int                 `json:"suppressed"`
+	ScoreNote          string              `json:"score_note"`
+	TopN               int                 `json:"top_n"`
+	IncludeSuggestions bool                `json:"include_suggestions"`
+	IncludeMetrics     bool                `json:"include_metrics"`
+	IncludeSnippets    bool                `json:"include_snippets"`
+}
+
+// generateHTML renders a single self-contained HTML page with embedded CSS
+// and JavaScript; it makes no external requests
+func (g *Generator) generateHTML(report *model.AnalysisReport) (string, error) {
+	data := htmlData{
+		RepoName:           report.RepoName,
+		GeneratedAt:        report.GeneratedAt.Format("2006-01-02 15:04:05 UTC"),
+		Summary:            report.Summary,
+		Issues:             make([]htmlIssue, 0, len(report.Issues)),
+		Suppressed:         len(report.Suppressed),
+		ScoreNote:          g.scoreNote(),
+		TopN:               g.cfg.HotspotsTopN,
+		IncludeSuggestions: g.cfg.IncludeSuggestions,
+		IncludeMetrics:     g.cfg.IncludeMetrics,
+		IncludeSnippets:    g.cfg.IncludeCodeSnippets,
+	}
+
+	for _, issue := range report.Issues {
+		if !g.cfg.IncludeCodeSnippets {
+			issue.CodeSnippet = ""
+		}
+		data.Issues = append(data.Issues, htmlIssue{
+			DebtIssue: issue,
+			Rule:      issue.RuleID(),
+			Detector:  detectorForCategory(issue.Category),
+			Directory: directoryOf(issue.FilePath),
+		})
+	}
+
+	// json.Marshal escapes <, > and &, so the payload cannot close the script tag
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return "", err
+	}
+
+	var sb strings.Builder
+	err = htmlTemplate.Execute(&sb, map[string]any{
+		"RepoName":    report.RepoName,
+		"GeneratedAt": data.GeneratedAt,
+		"Data":        template.JS(payload),
+	})
+	if err != nil {
+		return "", err
+	}
+	return sb.String(), nil
+}
+
+// detectorForCategory returns the name of the detector reporting a category
+func detectorForCategory(c model.Category) string {
+	switch c {
+	case model.CategorySize:
+		return "size_structure"
+	default:
+		return string(c)
+	}
+}
+
+func directoryOf(filePath string) string {
+	dir := path.Dir(strings.ReplaceAll(filePath, "\\", "/"))
+	if dir == "." || dir == "/" {
+		return "(root)"
+	}
+	return dir
+}
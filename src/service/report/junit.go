@@ -0,0 +1,129 @@
+package report
+
+import (
+	"encoding/xml"
+	"fmt"
+	"sort"
+	"strings"
+
+	"quality-bot/src/model"
+)
+
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Skipped  int              `xml:"skipped,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Skipped   int             `xml:"skipped,attr"`
+	Timestamp string          `xml:"timestamp,attr"`
+	Cases     []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	File      string        `xml:"file,attr,omitempty"`
+	Line      int           `xml:"line,attr,omitempty"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+	Skipped   *junitSkipped `xml:"skipped,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",cdata"`
+}
+
+type junitSkipped struct {
+	Message string `xml:"message,attr"`
+}
+
+// generateJUnit renders one testsuite per category and one testcase per
+// issue. Issues at or above the failure severity fail; less severe issues
+// are rendered as skipped or passing depending on configuration.
+func (g *Generator) generateJUnit(report *model.AnalysisReport) (string, error) {
+	failLevel := model.SeverityPriority(model.Severity(g.cfg.JUnit.FailureSeverity))
+	timestamp := report.GeneratedAt.Format("2006-01-02T15:04:05")
+
+	root := junitTestSuites{Name: "quality-bot: " + report.RepoName}
+	byCategory := sortedIssuesByCategory(report.Issues)
+
+	for _, cat := range categoryOrder {
+		suite := junitTestSuite{Name: string(cat), Timestamp: timestamp}
+
+		for _, issue := range byCategory[cat] {
+			tc := junitTestCase{
+				Name:      fmt.Sprintf("%s: %s", issue.Subcategory, issue.EntityName),
+				ClassName: issue.FilePath,
+				File:      issue.FilePath,
+				Line:      issue.StartLine,
+			}
+
+			switch {
+			case model.SeverityPriority(issue.Severity) <= failLevel:
+				tc.Failure = &junitFailure{
+					Message: fmt.Sprintf("%s:%d %s", issue.FilePath, issue.StartLine, issue.Description),
+					Type:    issue.RuleID(),
+					Text:    g.junitFailureText(issue),
+				}
+				suite.Failures++
+			case g.cfg.JUnit.BelowThreshold != "passed":
+				tc.Skipped = &junitSkipped{
+					Message: fmt.Sprintf("%s severity below %s: %s", issue.Severity, g.cfg.JUnit.FailureSeverity, issue.Description),
+				}
+				suite.Skipped++
+			}
+
+			suite.Cases = append(suite.Cases, tc)
+		}
+
+		// An empty suite still gets a passing case so dashboards show the category as green
+		if len(suite.Cases) == 0 {
+			suite.Cases = append(suite.Cases, junitTestCase{Name: "no issues", ClassName: string(cat)})
+		}
+
+		suite.Tests = len(suite.Cases)
+		root.Tests += suite.Tests
+		root.Failures += suite.Failures
+		root.Skipped += suite.Skipped
+		root.Suites = append(root.Suites, suite)
+	}
+
+	data, err := xml.MarshalIndent(root, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return xml.Header + string(data) + "\n", nil
+}
+
+func (g *Generator) junitFailureText(issue model.DebtIssue) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("%s:%d-%d\n", issue.FilePath, issue.StartLine, issue.EndLine))
+	sb.WriteString(fmt.Sprintf("Severity: %s\n", issue.Severity))
+	sb.WriteString(issue.Description + "\n")
+
+	if g.cfg.IncludeSuggestions && issue.Suggestion != "" {
+		sb.WriteString("Suggestion: " + issue.Suggestion + "\n")
+	}
+
+	if g.cfg.IncludeMetrics && len(issue.Metrics) > 0 {
+		keys := make([]string, 0, len(issue.Metrics))
+		for k := range issue.Metrics {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			sb.WriteString(fmt.Sprintf("  %s: %v\n", k, issue.Metrics[k]))
+		}
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,176 @@
+package report
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"quality-bot/src/model"
+	"quality-bot/src/util"
+)
+
+// GeneratePortfolio renders a portfolio report in the specified format
+// (markdown, json or csv). CSV contains one row per repository.
+func (g *Generator) GeneratePortfolio(p *model.PortfolioReport, format string) (string, error) {
+	util.Debug("Generating portfolio in %s format (%d repositories)", format, len(p.Repos))
+	switch format {
+	case "json":
+		data, err := json.MarshalIndent(p, "", "  ")
+		if err != nil {
+			return "", err
+		}
+		return string(data), nil
+	case "markdown", "md":
+		return g.generatePortfolioMarkdown(p), nil
+	case "csv":
+		return g.generatePortfolioCSV(p)
+	default:
+		return "", fmt.Errorf("unsupported portfolio format: %s", format)
+	}
+}
+
+func (g *Generator) generatePortfolioMarkdown(p *model.PortfolioReport) string {
+	var sb strings.Builder
+	t := p.Totals
+
+	sb.WriteString("# Technical Debt Portfolio\n\n")
+	sb.WriteString(fmt.Sprintf("**Generated:** %s\n", p.GeneratedAt.Format("2006-01-02 15:04:05 UTC")))
+	sb.WriteString(fmt.Sprintf("**Repositories:** %d analyzed", t.Analyzed))
+	if t.Failed > 0 {
+		sb.WriteString(fmt.Sprintf(", %d failed", t.Failed))
+	}
+	sb.WriteString("\n")
+	sb.WriteString(fmt.Sprintf("**Overall Debt Score:** %s per 1,000 lines (%d issues in %d lines)\n",
+		scoreLabel(t.DebtScore, t.DebtGrade), t.TotalIssues, t.LinesOfCode))
+	if t.RemediationMinutes > 0 {
+		sb.WriteString(fmt.Sprintf("**Remediation Effort:** %s\n", formatEffort(t.RemediationMinutes)))
+	}
+	sb.WriteString("\n")
+
+	sb.WriteString("## Ranking\n\n")
+	sb.WriteString("Repositories ranked by size-normalized debt score, worst first.\n\n")
+	sb.WriteString("| Rank | Repository | Debt Score | Issues | Lines | Effort | Debt Ratio | Gate |\n")
+	sb.WriteString("|------|------------|------------|--------|-------|--------|------------|------|\n")
+	for _, e := range p.Repos {
+		if e.Error != "" {
+			continue
+		}
+		sb.WriteString(fmt.Sprintf("| %d | %s | %s | %d | %d | %s | %s | %s |\n",
+			e.Rank, e.RepoName, scoreLabel(e.DebtScore, e.DebtGrade), e.TotalIssues, e.LinesOfCode,
+			formatEffort(e.RemediationMinutes), formatRatio(e.DebtRatio), gateLabel(e.GatePassed)))
+	}
+	sb.WriteString("\n")
+
+	sb.WriteString("## Category Mix\n\n")
+	sb.WriteString("Share of each repository's issues per category.\n\n")
+	sb.WriteString("| Repository |")
+	for _, cat := range categoryOrder {
+		sb.WriteString(fmt.Sprintf(" %s |", cat))
+	}
+	sb.WriteString("\n|------------|" + strings.Repeat("---|", len(categoryOrder)) + "\n")
+	sb.WriteString("| **All** |")
+	for _, cat := range categoryOrder {
+		sb.WriteString(fmt.Sprintf(" %.0f%% |", p.CategoryMix[cat]))
+	}
+	sb.WriteString("\n")
+	for _, e := range p.Repos {
+		if e.Error != "" {
+			continue
+		}
+		sb.WriteString(fmt.Sprintf("| %s |", e.RepoName))
+		for _, cat := range categoryOrder {
+			sb.WriteString(fmt.Sprintf(" %.0f%% (%d) |", e.CategoryMix[cat], e.ByCategory[cat]))
+		}
+		sb.WriteString("\n")
+	}
+	sb.WriteString("\n")
+
+	if t.Failed > 0 {
+		sb.WriteString("## Failed Repositories\n\n")
+		sb.WriteString("| Repository | Error |\n")
+		sb.WriteString("|------------|-------|\n")
+		for _, e := range p.Repos {
+			if e.Error != "" {
+				sb.WriteString(fmt.Sprintf("| %s | %s |\n", e.RepoName, strings.ReplaceAll(e.Error, "|", "\\|")))
+			}
+		}
+		sb.WriteString("\n")
+	}
+
+	g.writeScoreNote(&sb)
+	return sb.String()
+}
+
+func (g *Generator) generatePortfolioCSV(p *model.PortfolioReport) (string, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+
+	header := []string{"rank", "repo", "debt_score", "debt_grade", "total_issues", "lines_of_code",
+		"remediation_minutes", "debt_ratio", "quality_gate_passed"}
+	for _, cat := range categoryOrder {
+		header = append(header, "category_"+string(cat))
+	}
+	for _, sev := range severityOrder {
+		header = append(header, "severity_"+string(sev))
+	}
+	header = append(header, "error")
+	if err := w.Write(header); err != nil {
+		return "", err
+	}
+
+	for _, e := range p.Repos {
+		rank := ""
+		if e.Rank > 0 {
+			rank = strconv.Itoa(e.Rank)
+		}
+		gate := ""
+		if e.GatePassed != nil {
+			gate = strconv.FormatBool(*e.GatePassed)
+		}
+		row := []string{
+			rank,
+			e.RepoName,
+			strconv.FormatFloat(e.DebtScore, 'f', 2, 64),
+			e.DebtGrade,
+			strconv.Itoa(e.TotalIssues),
+			strconv.Itoa(e.LinesOfCode),
+			strconv.Itoa(e.RemediationMinutes),
+			strconv.FormatFloat(e.DebtRatio, 'f', 2, 64),
+			gate,
+		}
+		for _, cat := range categoryOrder {
+			row = append(row, strconv.Itoa(e.ByCategory[cat]))
+		}
+		for _, sev := range severityOrder {
+			row = append(row, strconv.Itoa(e.BySeverity[sev]))
+		}
+		row = append(row, e.Error)
+		if err := w.Write(row); err != nil {
+			return "", err
+		}
+	}
+
+	w.Flush()
+	return buf.String(), w.Error()
+}
+
+func formatRatio(ratio float64) string {
+	if ratio <= 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%.1f%%", ratio)
+}
+
+func gateLabel(passed *bool) string {
+	switch {
+	case passed == nil:
+		return "-"
+	case *passed:
+		return "passed"
+	default:
+		return "failed"
+	}
+}
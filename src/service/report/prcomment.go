@@ -0,0 +1,221 @@
+package report
+
+import (
+	"fmt"
+	"html"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"quality-bot/src/model"
+	"quality-bot/src/util"
+)
+
+// truncationNote is appended when the comment had to be cut to fit the limit
+const truncationNote = "\n\n_Report truncated to fit the comment size limit. See the full report artifact for all issues._\n"
+
+// generatePRComment renders a compact Markdown summary suitable for a pull
+// request comment. If the result exceeds the configured length, fewer issues
+// are listed per category until it fits.
+func (g *Generator) generatePRComment(report *model.AnalysisReport) (string, error) {
+	maxLen := g.cfg.PRComment.MaxLength
+	topN := g.cfg.PRComment.TopN
+
+	newIssues := newIssueCounts(report)
+	issuesByCategory := sortedIssuesByCategory(report.Issues)
+
+	for n := topN; n >= 0; n = shrink(n) {
+		comment := g.renderPRComment(report, issuesByCategory, newIssues, n)
+		if maxLen <= 0 || utf8.RuneCountInString(comment) <= maxLen {
+			if n < topN {
+				util.Debug("PR comment: reduced to %d issues per category to fit %d characters", n, maxLen)
+			}
+			return comment, nil
+		}
+		if n == 0 {
+			break
+		}
+	}
+
+	// Even the bare summary is too long; cut at a rune boundary
+	comment := g.renderPRComment(report, issuesByCategory, newIssues, 0)
+	runes := []rune(comment)
+	cut := max(maxLen-utf8.RuneCountInString(truncationNote), 0)
+	util.Warn("PR comment exceeds %d characters even without issues; truncating", maxLen)
+	return string(runes[:min(cut, len(runes))]) + truncationNote, nil
+}
+
+func (g *Generator) renderPRComment(report *model.AnalysisReport, byCategory map[model.Category][]model.DebtIssue, newIssues map[string]int, topN int) string {
+	var sb strings.Builder
+	summary := report.Summary
+
+	sb.WriteString(fmt.Sprintf("## Technical Debt: %s\n\n", report.RepoName))
+
+	sb.WriteString("| Debt Score | Issues |")
+	for _, sev := range severityOrder {
+		sb.WriteString(fmt.Sprintf(" %s |", strings.Title(string(sev))))
+	}
+	sb.WriteString("\n|---|---|" + strings.Repeat("---|", len(severityOrder)) + "\n")
+	sb.WriteString(fmt.Sprintf("| %s | %d |", scoreLabel(summary.DebtScore, summary.DebtGrade), summary.TotalIssues))
+	for _, sev := range severityOrder {
+		sb.WriteString(fmt.Sprintf(" %d |", summary.BySeverity[sev]))
+	}
+	sb.WriteString("\n\n")
+
+	if r := summary.Remediation; r != nil && r.TotalMinutes > 0 {
+		sb.WriteString(fmt.Sprintf("**Estimated remediation effort:** %s", formatEffort(r.TotalMinutes)))
+		if r.LinesOfCode > 0 {
+			sb.WriteString(fmt.Sprintf(" (debt ratio %.2f%%)", r.DebtRatio))
+		}
+		sb.WriteString("\n\n")
+	}
+
+	if report.Baseline != nil {
+		sb.WriteString(fmt.Sprintf("**Compared to baseline:** %d new, %d fixed\n\n",
+			len(report.Baseline.NewIssues), len(report.Baseline.FixedIssues)))
+	}
+
+	if report.QualityGate != nil {
+		verdict := "passed"
+		if !report.QualityGate.Passed {
+			verdict = "**failed**"
+			var reasons []string
+			for _, c := range report.QualityGate.Failures() {
+				reasons = append(reasons, fmt.Sprintf("%s: %g > %g", c.Name, c.Actual, c.Threshold))
+			}
+			verdict += " (" + strings.Join(reasons, "; ") + ")"
+		}
+		sb.WriteString(fmt.Sprintf("**Quality gate:** %s\n\n", verdict))
+	}
+
+	// Copy so marking issues as new does not consume the shared counts
+	remainingNew := make(map[string]int, len(newIssues))
+	for fp, n := range newIssues {
+		remainingNew[fp] = n
+	}
+
+	truncated := false
+	for _, cat := range categoryOrder {
+		issues := byCategory[cat]
+		if len(issues) == 0 {
+			continue
+		}
+
+		// The summary counts issues beyond output.max_issues_per_category
+		total := max(len(issues), report.Summary.ByCategory[cat])
+		shown := min(topN, len(issues))
+		label := fmt.Sprintf("%d issues", total)
+		if shown < total {
+			label += fmt.Sprintf(", top %d shown", shown)
+			truncated = true
+		}
+
+		sb.WriteString(fmt.Sprintf("<details>\n<summary><b>%s</b> (%s)</summary>\n\n", strings.Title(string(cat)), label))
+		if shown > 0 {
+			sb.WriteString("| Severity | Rule | Entity | Location | Description |\n")
+			sb.WriteString("|----------|------|--------|----------|-------------|\n")
+			for _, issue := range issues[:shown] {
+				severity := string(issue.Severity)
+				if fp := issue.Fingerprint(); remainingNew[fp] > 0 {
+					remainingNew[fp]--
+					severity += " (new)"
+				}
+				sb.WriteString(fmt.Sprintf("| %s | %s | %s | %s | %s |\n",
+					severity, issue.Subcategory, codeSpan(escapeTableCell(issue.EntityName)), g.issueLink(issue), escapeTableCell(html.EscapeString(issue.Description))))
+			}
+		}
+		sb.WriteString("\n</details>\n\n")
+	}
+
+	if report.Baseline != nil && len(report.Baseline.FixedIssues) > 0 {
+		fixed := report.Baseline.FixedIssues
+		shown := min(topN, len(fixed))
+		sb.WriteString(fmt.Sprintf("<details>\n<summary><b>Fixed</b> (%d issues)</summary>\n\n", len(fixed)))
+		for _, issue := range fixed[:shown] {
+			sb.WriteString(fmt.Sprintf("- %s %s in %s\n", issue.RuleID(), codeSpan(escapeTableCell(issue.EntityName)), codeSpan(issue.FilePath)))
+		}
+		if shown < len(fixed) {
+			sb.WriteString(fmt.Sprintf("- _and %d more_\n", len(fixed)-shown))
+			truncated = true
+		}
+		sb.WriteString("\n</details>\n\n")
+	}
+
+	if truncated {
+		sb.WriteString("_Only the most severe issues are listed. See the full report for details._\n")
+	}
+
+	return sb.String()
+}
+
+// issueLink returns a "file#Lstart-Lend" reference, as a link when a base
+// URL is configured
+func (g *Generator) issueLink(issue model.DebtIssue) string {
+	ref := fmt.Sprintf("%s#L%d", issue.FilePath, issue.StartLine)
+	if issue.EndLine > issue.StartLine {
+		ref += fmt.Sprintf("-L%d", issue.EndLine)
+	}
+
+	if g.cfg.PRComment.LinkBase == "" {
+		return codeSpan(ref)
+	}
+	base := strings.TrimSuffix(g.cfg.PRComment.LinkBase, "/")
+	return fmt.Sprintf("[%s](%s/%s)", ref, base, strings.TrimPrefix(ref, "/"))
+}
+
+// sortedIssuesByCategory groups issues by category, most severe first
+func sortedIssuesByCategory(issues []model.DebtIssue) map[model.Category][]model.DebtIssue {
+	byCategory := make(map[model.Category][]model.DebtIssue)
+	for _, issue := range issues {
+		byCategory[issue.Category] = append(byCategory[issue.Category], issue)
+	}
+	for cat := range byCategory {
+		sort.SliceStable(byCategory[cat], func(i, j int) bool {
+			return model.SeverityPriority(byCategory[cat][i].Severity) < model.SeverityPriority(byCategory[cat][j].Severity)
+		})
+	}
+	return byCategory
+}
+
+func newIssueCounts(report *model.AnalysisReport) map[string]int {
+	counts := make(map[string]int)
+	if report.Baseline != nil {
+		for _, fp := range report.Baseline.NewIssues {
+			counts[fp]++
+		}
+	}
+	return counts
+}
+
+// shrink returns the next smaller issue count to try when the comment is too long
+func shrink(n int) int {
+	if n <= 1 {
+		return n - 1
+	}
+	return n / 2
+}
+
+func escapeTableCell(s string) string {
+	s = strings.ReplaceAll(s, "|", "\\|")
+	return strings.ReplaceAll(s, "\n", " ")
+}
+
+// codeSpan renders s as inline code. The comment sits inside HTML <details>
+// blocks; text in a code span is never parsed as HTML, and the fence is
+// longer than any backtick run in s so s cannot end the span early.
+func codeSpan(s string) string {
+	longest, run := 0, 0
+	for _, r := range s {
+		if r == '`' {
+			run++
+			longest = max(longest, run)
+		} else {
+			run = 0
+		}
+	}
+	fence := strings.Repeat("`", longest+1)
+	if longest > 0 {
+		return fence + " " + s + " " + fence
+	}
+	return fence + s + fence
+}
@@ -0,0 +1,101 @@
+package report
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"quality-bot/src/model"
+	"quality-bot/src/util"
+)
+
+// GenerateQueryResult renders the rows of an ad-hoc query in the specified
+// format (table, json or csv). Nested values are encoded as JSON in table
+// and CSV cells.
+func (g *Generator) GenerateQueryResult(res *model.QueryResult, format string) (string, error) {
+	util.Debug("Generating query result in %s format (%d rows)", format, len(res.Rows))
+	switch format {
+	case "table":
+		return generateQueryTable(res), nil
+	case "json":
+		data, err := json.MarshalIndent(res, "", "  ")
+		if err != nil {
+			return "", err
+		}
+		return string(data), nil
+	case "csv":
+		return generateQueryCSV(res)
+	default:
+		return "", fmt.Errorf("unsupported query format: %s", format)
+	}
+}
+
+func generateQueryTable(res *model.QueryResult) string {
+	var sb strings.Builder
+	w := tabwriter.NewWriter(&sb, 0, 0, 2, ' ', 0)
+
+	fmt.Fprintln(w, strings.Join(res.Columns, "\t"))
+	rule := make([]string, len(res.Columns))
+	for i, col := range res.Columns {
+		rule[i] = strings.Repeat("-", len(col))
+	}
+	fmt.Fprintln(w, strings.Join(rule, "\t"))
+
+	for _, row := range res.Rows {
+		cells := make([]string, len(res.Columns))
+		for i, col := range res.Columns {
+			cells[i] = strings.NewReplacer("\t", " ", "\n", " ").Replace(cellValue(row[col]))
+		}
+		fmt.Fprintln(w, strings.Join(cells, "\t"))
+	}
+	w.Flush()
+
+	sb.WriteString(fmt.Sprintf("(%d rows)", len(res.Rows)))
+	return sb.String()
+}
+
+func generateQueryCSV(res *model.QueryResult) (string, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+
+	if err := w.Write(res.Columns); err != nil {
+		return "", err
+	}
+	for _, row := range res.Rows {
+		record := make([]string, len(res.Columns))
+		for i, col := range res.Columns {
+			record[i] = cellValue(row[col])
+		}
+		if err := w.Write(record); err != nil {
+			return "", err
+		}
+	}
+
+	w.Flush()
+	return buf.String(), w.Error()
+}
+
+// cellValue renders a query value as a single cell; missing and null values
+// are empty
+func cellValue(v any) string {
+	switch val := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return val
+	case float64:
+		return strconv.FormatFloat(val, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(val)
+	default:
+		data, err := json.Marshal(val)
+		if err != nil {
+			return fmt.Sprint(val)
+		}
+		return string(data)
+	}
+}
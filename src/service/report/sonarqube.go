@@ -0,0 +1,85 @@
+package report
+
+import (
+	"encoding/json"
+
+	"quality-bot/src/model"
+)
+
+// sonarEngineID identifies quality-bot as the external engine in SonarQube
+const sonarEngineID = "quality-bot"
+
+// sonarReport is the SonarQube Generic Issue Import format, imported with
+// the sonar.externalIssuesReportPaths analysis parameter
+type sonarReport struct {
+	Issues []sonarIssue `json:"issues"`
+}
+
+type sonarIssue struct {
+	EngineID        string        `json:"engineId"`
+	RuleID          string        `json:"ruleId"`
+	Severity        string        `json:"severity"`
+	Type            string        `json:"type"`
+	EffortMinutes   int           `json:"effortMinutes,omitempty"`
+	PrimaryLocation sonarLocation `json:"primaryLocation"`
+}
+
+type sonarLocation struct {
+	Message   string          `json:"message"`
+	FilePath  string          `json:"filePath"`
+	TextRange *sonarTextRange `json:"textRange,omitempty"`
+}
+
+type sonarTextRange struct {
+	StartLine int `json:"startLine"`
+	EndLine   int `json:"endLine,omitempty"`
+}
+
+func (g *Generator) generateSonarQube(report *model.AnalysisReport) (string, error) {
+	issues := make([]sonarIssue, 0, len(report.Issues))
+
+	for _, issue := range report.Issues {
+		location := sonarLocation{
+			Message:  issue.Description,
+			FilePath: issue.FilePath,
+		}
+		// SonarQube rejects ranges starting at line 0, so file-level issues
+		// are reported without one
+		if issue.StartLine > 0 {
+			location.TextRange = &sonarTextRange{
+				StartLine: issue.StartLine,
+				EndLine:   max(issue.EndLine, issue.StartLine),
+			}
+		}
+
+		issues = append(issues, sonarIssue{
+			EngineID:        sonarEngineID,
+			RuleID:          issue.RuleID(),
+			Severity:        sonarSeverity(issue.Severity),
+			Type:            "CODE_SMELL",
+			EffortMinutes:   issue.EffortMinutes,
+			PrimaryLocation: location,
+		})
+	}
+
+	data, err := json.MarshalIndent(sonarReport{Issues: issues}, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// sonarSeverity maps severities onto the SonarQube scale
+// (INFO, MINOR, MAJOR, CRITICAL, BLOCKER)
+func sonarSeverity(s model.Severity) string {
+	switch s {
+	case model.SeverityCritical:
+		return "BLOCKER"
+	case model.SeverityHigh:
+		return "CRITICAL"
+	case model.SeverityMedium:
+		return "MAJOR"
+	default:
+		return "MINOR"
+	}
+}
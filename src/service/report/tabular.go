@@ -0,0 +1,63 @@
+package report
+
+import (
+	"sort"
+	"strings"
+
+	"quality-bot/src/model"
+	"quality-bot/src/service/export"
+)
+
+// metricPrefix marks flattened metric columns in tabular exports
+const metricPrefix = "metric."
+
+// issueColumns are the fixed leading columns of tabular issue exports
+var issueColumns = []string{
+	"fingerprint", "rule", "category", "subcategory", "severity",
+	"file_path", "start_line", "end_line", "entity_name", "entity_type",
+	"description", "suggestion", "effort_minutes", "owners", "profile",
+}
+
+// issueTable flattens issues into rows; each metric key becomes its own
+// "metric.<name>" column so the data loads straight into a spreadsheet
+func (g *Generator) issueTable(report *model.AnalysisReport) export.Table {
+	metricKeys := make(map[string]bool)
+	for _, issue := range report.Issues {
+		for k := range issue.Metrics {
+			metricKeys[metricPrefix+k] = true
+		}
+	}
+
+	var metricColumns []string
+	for k := range metricKeys {
+		metricColumns = append(metricColumns, k)
+	}
+	sort.Strings(metricColumns)
+
+	table := export.Table{Header: append(append([]string{}, issueColumns...), metricColumns...)}
+	for _, issue := range report.Issues {
+		row := map[string]any{
+			"fingerprint":    issue.Fingerprint(),
+			"rule":           issue.RuleID(),
+			"category":       issue.Category,
+			"subcategory":    issue.Subcategory,
+			"severity":       issue.Severity,
+			"file_path":      issue.FilePath,
+			"start_line":     issue.StartLine,
+			"end_line":       issue.EndLine,
+			"entity_name":    issue.EntityName,
+			"entity_type":    issue.EntityType,
+			"description":    issue.Description,
+			"suggestion":     issue.Suggestion,
+			"effort_minutes": issue.EffortMinutes,
+			"owners":         strings.Join(issue.Owners, " "),
+			"profile":        issue.Profile,
+		}
+		for k, v := range issue.Metrics {
+			row[metricPrefix+k] = v
+		}
+		table.Rows = append(table.Rows, row)
+	}
+
+	return table
+}
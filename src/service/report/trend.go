@@ -0,0 +1,133 @@
+package report
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"quality-bot/src/model"
+	"quality-bot/src/util"
+)
+
+// GenerateTrend renders a trend report in the specified format
+// (markdown, json or csv). CSV contains one row per recorded run.
+func (g *Generator) GenerateTrend(trend *model.TrendReport, format string) (string, error) {
+	util.Debug("Generating trend in %s format (%d points)", format, len(trend.Points))
+	switch format {
+	case "json":
+		data, err := json.MarshalIndent(trend, "", "  ")
+		if err != nil {
+			return "", err
+		}
+		return string(data), nil
+	case "markdown", "md":
+		return g.generateTrendMarkdown(trend), nil
+	case "csv":
+		return g.generateTrendCSV(trend)
+	default:
+		return "", fmt.Errorf("unsupported trend format: %s", format)
+	}
+}
+
+func (g *Generator) generateTrendMarkdown(trend *model.TrendReport) string {
+	var sb strings.Builder
+
+	sb.WriteString("# Technical Debt Trend\n\n")
+	sb.WriteString(fmt.Sprintf("**Repository:** %s\n", trend.RepoName))
+	sb.WriteString(fmt.Sprintf("**Runs:** %d\n\n", len(trend.Points)))
+
+	sb.WriteString("## Debt Over Time\n\n")
+	sb.WriteString("| Date | Commit | Debt Score | Issues |")
+	for _, cat := range categoryOrder {
+		sb.WriteString(fmt.Sprintf(" %s |", cat))
+	}
+	for _, sev := range severityOrder {
+		sb.WriteString(fmt.Sprintf(" %s |", sev))
+	}
+	sb.WriteString("\n|------|--------|------------|--------|")
+	sb.WriteString(strings.Repeat("---|", len(categoryOrder)+len(severityOrder)))
+	sb.WriteString("\n")
+
+	for _, p := range trend.Points {
+		sb.WriteString(fmt.Sprintf("| %s | %s | %s | %d |",
+			p.GeneratedAt.Format("2006-01-02 15:04"), shortCommit(p.Commit), scoreLabel(p.DebtScore, p.DebtGrade), p.TotalIssues))
+		for _, cat := range categoryOrder {
+			sb.WriteString(fmt.Sprintf(" %d |", p.ByCategory[cat]))
+		}
+		for _, sev := range severityOrder {
+			sb.WriteString(fmt.Sprintf(" %d |", p.BySeverity[sev]))
+		}
+		sb.WriteString("\n")
+	}
+	sb.WriteString("\n")
+
+	if len(trend.Points) > 1 {
+		first, last := trend.Points[0], trend.Points[len(trend.Points)-1]
+		sb.WriteString(fmt.Sprintf("**Change:** %+d issues, %+.1f debt score since %s\n\n",
+			last.TotalIssues-first.TotalIssues, last.DebtScore-first.DebtScore, first.GeneratedAt.Format("2006-01-02")))
+	}
+
+	sb.WriteString("## Top Regressing Files\n\n")
+	if len(trend.RegressingFiles) == 0 {
+		sb.WriteString("No file gained issues in this window.\n")
+		return sb.String()
+	}
+	sb.WriteString("| File | Before | After | Change |\n")
+	sb.WriteString("|------|--------|-------|--------|\n")
+	for _, f := range trend.RegressingFiles {
+		sb.WriteString(fmt.Sprintf("| %s | %d | %d | %+d |\n", f.FilePath, f.FirstCount, f.LastCount, f.Delta))
+	}
+
+	return sb.String()
+}
+
+func (g *Generator) generateTrendCSV(trend *model.TrendReport) (string, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+
+	header := []string{"generated_at", "commit", "debt_score", "debt_grade", "total_issues"}
+	for _, cat := range categoryOrder {
+		header = append(header, "category_"+string(cat))
+	}
+	for _, sev := range severityOrder {
+		header = append(header, "severity_"+string(sev))
+	}
+	if err := w.Write(header); err != nil {
+		return "", err
+	}
+
+	for _, p := range trend.Points {
+		row := []string{
+			p.GeneratedAt.Format("2006-01-02T15:04:05Z07:00"),
+			p.Commit,
+			strconv.FormatFloat(p.DebtScore, 'f', 2, 64),
+			p.DebtGrade,
+			strconv.Itoa(p.TotalIssues),
+		}
+		for _, cat := range categoryOrder {
+			row = append(row, strconv.Itoa(p.ByCategory[cat]))
+		}
+		for _, sev := range severityOrder {
+			row = append(row, strconv.Itoa(p.BySeverity[sev]))
+		}
+		if err := w.Write(row); err != nil {
+			return "", err
+		}
+	}
+
+	w.Flush()
+	return buf.String(), w.Error()
+}
+
+func shortCommit(commit string) string {
+	if len(commit) > 8 {
+		return commit[:8]
+	}
+	if commit == "" {
+		return "-"
+	}
+	return commit
+}
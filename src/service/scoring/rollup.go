@@ -0,0 +1,112 @@
+package scoring
+
+import (
+	"path"
+	"sort"
+	"strings"
+
+	"quality-bot/src/model"
+)
+
+// rootPath is the path of the repository root in the directory tree
+const rootPath = "."
+
+// rollupBuilder accumulates subtree totals while walking files and issues
+type rollupBuilder struct {
+	scorer      *Scorer
+	nodes       map[string]*model.DirectoryRollup
+	worstWeight map[string]float64
+}
+
+// Rollup builds the directory tree with issue counts, lines, score and worst
+// entity of every directory, each including its whole subtree
+func (s *Scorer) Rollup(issues []model.DebtIssue, files []model.FileMetrics) *model.DirectoryRollup {
+	b := &rollupBuilder{
+		scorer:      s,
+		nodes:       make(map[string]*model.DirectoryRollup),
+		worstWeight: make(map[string]float64),
+	}
+	root := b.node(rootPath)
+
+	for _, f := range files {
+		for _, dir := range ancestors(f.Path) {
+			b.node(dir).LinesOfCode += f.LineCount
+		}
+	}
+
+	for _, issue := range issues {
+		weight := s.Weight(issue)
+		for _, dir := range ancestors(issue.FilePath) {
+			n := b.node(dir)
+			n.TotalIssues++
+			n.ByCategory[issue.Category]++
+			n.BySeverity[issue.Severity]++
+			n.WeightedIssues += weight
+
+			if n.WorstEntity == nil || weight > b.worstWeight[dir] {
+				b.worstWeight[dir] = weight
+				n.WorstEntity = &model.EntityRef{
+					Name:     issue.EntityName,
+					Type:     issue.EntityType,
+					FilePath: issue.FilePath,
+					Rule:     issue.RuleID(),
+					Severity: issue.Severity,
+				}
+			}
+		}
+	}
+
+	b.finish(root)
+	return root
+}
+
+// node returns the tree node of a directory, creating it and linking it to
+// its parent on first use
+func (b *rollupBuilder) node(dir string) *model.DirectoryRollup {
+	if n, ok := b.nodes[dir]; ok {
+		return n
+	}
+
+	n := &model.DirectoryRollup{
+		Path:       dir,
+		Name:       path.Base(dir),
+		ByCategory: make(map[model.Category]int),
+		BySeverity: make(map[model.Severity]int),
+	}
+	b.nodes[dir] = n
+
+	if dir != rootPath {
+		parent := b.node(path.Dir(dir))
+		parent.Children = append(parent.Children, n)
+	}
+	return n
+}
+
+// finish scores every node and orders children by path
+func (b *rollupBuilder) finish(n *model.DirectoryRollup) {
+	n.DebtScore = b.scorer.Score(n.WeightedIssues, n.LinesOfCode)
+	n.DebtGrade = b.scorer.Grade(n.DebtScore)
+
+	sort.Slice(n.Children, func(i, j int) bool {
+		return n.Children[i].Path < n.Children[j].Path
+	})
+	for _, c := range n.Children {
+		b.finish(c)
+	}
+}
+
+// ancestors returns the directories containing a file, from the nearest up
+// to the repository root
+func ancestors(filePath string) []string {
+	dir := strings.TrimPrefix(directoryOf(filePath), "/")
+	if dir == "" {
+		dir = rootPath
+	}
+
+	dirs := []string{dir}
+	for dir != rootPath {
+		dir = path.Dir(dir)
+		dirs = append(dirs, dir)
+	}
+	return dirs
+}
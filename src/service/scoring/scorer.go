@@ -0,0 +1,109 @@
+package scoring
+
+import (
+	"path"
+	"sort"
+	"strings"
+
+	"quality-bot/src/config"
+	"quality-bot/src/model"
+)
+
+// Grade letters from best to worst
+var grades = []string{"A", "B", "C", "D", "E"}
+
+// Scorer computes size-normalized debt scores: severity-weighted issues per
+// 1,000 lines of code, mapped to letter grades
+type Scorer struct {
+	cfg config.ScoringConfig
+}
+
+// NewScorer creates a new debt scorer
+func NewScorer(cfg config.ScoringConfig) *Scorer {
+	return &Scorer{cfg: cfg}
+}
+
+// Weight returns the configured weight of an issue's severity
+func (s *Scorer) Weight(issue model.DebtIssue) float64 {
+	return s.cfg.SeverityWeights[string(issue.Severity)]
+}
+
+// Score normalizes an issue weight by code size. Areas smaller than MinLines
+// count as MinLines so a single issue in a tiny file does not dominate.
+func (s *Scorer) Score(weight float64, lines int) float64 {
+	lines = max(lines, s.cfg.MinLines, 1)
+	return weight / (float64(lines) / 1000)
+}
+
+// Grade maps a score onto the configured bands; anything above the D bound is E
+func (s *Scorer) Grade(score float64) string {
+	bands := []float64{s.cfg.Grades.A, s.cfg.Grades.B, s.cfg.Grades.C, s.cfg.Grades.D}
+	for i, upper := range bands {
+		if score <= upper {
+			return grades[i]
+		}
+	}
+	return grades[len(grades)-1]
+}
+
+// Apply sets the repository score and grade on the summary, along with
+// scores for every file and directory that has issues and the directory tree.
+// issues must be the full list, before any per-category listing cap.
+func (s *Scorer) Apply(summary *model.ReportSummary, issues []model.DebtIssue, files []model.FileMetrics) {
+	fileLines := make(map[string]int, len(files))
+	dirLines := make(map[string]int)
+	totalLines := 0
+	for _, f := range files {
+		fileLines[f.Path] = f.LineCount
+		dirLines[directoryOf(f.Path)] += f.LineCount
+		totalLines += f.LineCount
+	}
+
+	fileWeights := make(map[string]float64)
+	dirWeights := make(map[string]float64)
+	totalWeight := 0.0
+	for _, issue := range issues {
+		w := s.Weight(issue)
+		fileWeights[issue.FilePath] += w
+		dirWeights[directoryOf(issue.FilePath)] += w
+		totalWeight += w
+	}
+
+	summary.LinesOfCode = totalLines
+	summary.WeightedIssues = totalWeight
+	summary.DebtScore = s.Score(totalWeight, totalLines)
+	summary.DebtGrade = s.Grade(summary.DebtScore)
+	summary.FileScores = s.areaScores(fileWeights, fileLines)
+	summary.DirectoryScores = s.areaScores(dirWeights, dirLines)
+	summary.Directories = s.Rollup(issues, files)
+}
+
+// areaScores scores every area with a non-zero weight, worst first
+func (s *Scorer) areaScores(weights map[string]float64, lines map[string]int) []model.AreaScore {
+	var scores []model.AreaScore
+	for p, w := range weights {
+		if w == 0 {
+			continue
+		}
+		score := s.Score(w, lines[p])
+		scores = append(scores, model.AreaScore{
+			Path:           p,
+			Lines:          lines[p],
+			WeightedIssues: w,
+			Score:          score,
+			Grade:          s.Grade(score),
+		})
+	}
+
+	sort.Slice(scores, func(i, j int) bool {
+		if scores[i].Score != scores[j].Score {
+			return scores[i].Score > scores[j].Score
+		}
+		return scores[i].Path < scores[j].Path
+	})
+	return scores
+}
+
+func directoryOf(filePath string) string {
+	return path.Dir(strings.ReplaceAll(filePath, "\\", "/"))
+}
@@ -0,0 +1,141 @@
+package source
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"quality-bot/src/service/codeapi"
+	"quality-bot/src/util"
+)
+
+// Line is a single numbered line of source code
+type Line struct {
+	Number int
+	Text   string
+}
+
+// Reader reads ranges of source lines from the analyzed repository
+type Reader interface {
+	// ReadLines returns lines startLine..endLine (inclusive, 1-based).
+	// Ranges past the end of the file are truncated.
+	ReadLines(ctx context.Context, filePath string, startLine, endLine int) ([]Line, error)
+}
+
+// CodeAPIReader reads source lines through the CodeAPI snippet endpoint
+type CodeAPIReader struct {
+	client   *codeapi.Client
+	repoName string
+
+	mu    sync.Mutex
+	cache map[string][]Line
+}
+
+// NewCodeAPIReader creates a reader backed by CodeAPI
+func NewCodeAPIReader(client *codeapi.Client, repoName string) *CodeAPIReader {
+	return &CodeAPIReader{
+		client:   client,
+		repoName: repoName,
+		cache:    make(map[string][]Line),
+	}
+}
+
+// ReadLines fetches a snippet from CodeAPI, caching identical ranges
+func (r *CodeAPIReader) ReadLines(ctx context.Context, filePath string, startLine, endLine int) ([]Line, error) {
+	startLine = max(startLine, 1)
+	key := fmt.Sprintf("%s:%d-%d", filePath, startLine, endLine)
+
+	r.mu.Lock()
+	if lines, ok := r.cache[key]; ok {
+		r.mu.Unlock()
+		return lines, nil
+	}
+	r.mu.Unlock()
+
+	resp, err := r.client.GetSnippet(ctx, r.repoName, filePath, startLine, endLine)
+	if err != nil {
+		return nil, err
+	}
+
+	first := resp.StartLine
+	if first <= 0 {
+		first = startLine
+	}
+
+	var lines []Line
+	for i, text := range strings.Split(resp.Code, "\n") {
+		lines = append(lines, Line{Number: first + i, Text: text})
+	}
+
+	r.mu.Lock()
+	r.cache[key] = lines
+	r.mu.Unlock()
+
+	return lines, nil
+}
+
+// LocalReader reads source lines from a local checkout
+type LocalReader struct {
+	root string
+
+	mu    sync.Mutex
+	files map[string][]string
+}
+
+// NewLocalReader creates a reader rooted at a local checkout directory
+func NewLocalReader(root string) *LocalReader {
+	return &LocalReader{
+		root:  root,
+		files: make(map[string][]string),
+	}
+}
+
+// ReadLines reads lines from the file on disk, caching whole files
+func (r *LocalReader) ReadLines(ctx context.Context, filePath string, startLine, endLine int) ([]Line, error) {
+	content, err := r.load(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	startLine = max(startLine, 1)
+	endLine = min(endLine, len(content))
+
+	var lines []Line
+	for n := startLine; n <= endLine; n++ {
+		lines = append(lines, Line{Number: n, Text: content[n-1]})
+	}
+	return lines, nil
+}
+
+func (r *LocalReader) load(filePath string) ([]string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if content, ok := r.files[filePath]; ok {
+		return content, nil
+	}
+
+	f, err := os.Open(filepath.Join(r.root, filepath.FromSlash(filePath)))
+	if err != nil {
+		return nil, fmt.Errorf("opening source file: %w", err)
+	}
+	defer f.Close()
+
+	var content []string
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 4*1024*1024)
+	for scanner.Scan() {
+		content = append(content, scanner.Text())
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading source file: %w", err)
+	}
+
+	util.Debug("Loaded %s from local checkout (%d lines)", filePath, len(content))
+	r.files[filePath] = content
+	return content, nil
+}
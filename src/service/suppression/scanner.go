@@ -0,0 +1,266 @@
+package suppression
+
+import (
+	"context"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"quality-bot/src/config"
+	"quality-bot/src/model"
+	"quality-bot/src/service/source"
+	"quality-bot/src/util"
+)
+
+// Marker is the annotation keyword recognized in source comments, e.g.
+//
+//	// quality-bot:ignore complexity/cyclomatic_complexity reason="parser state machine"
+const Marker = "quality-bot:ignore"
+
+var reasonPattern = regexp.MustCompile(`reason=(?:"([^"]*)"|'([^']*)')`)
+
+// dashCommentExts are the extensions of languages where "--" starts a comment
+var dashCommentExts = map[string]bool{".sql": true, ".lua": true, ".hs": true}
+
+// annotation is a parsed suppression comment
+type annotation struct {
+	rules  []string // Empty means every rule
+	reason string
+	line   int
+}
+
+// Scanner finds inline suppression comments for issues and filters them out
+type Scanner struct {
+	reader   source.Reader
+	lookback int
+}
+
+// NewScanner creates a suppression scanner reading source through reader
+func NewScanner(reader source.Reader, cfg config.SuppressionConfig) *Scanner {
+	return &Scanner{
+		reader:   reader,
+		lookback: cfg.Lookback,
+	}
+}
+
+// fileSource holds the lines read from one file, or the error reading them
+type fileSource struct {
+	lines []source.Line
+	err   error
+}
+
+// Apply splits issues into those that remain and those suppressed by an
+// annotation on or directly above the issue's entity. Each file is read once,
+// up to the last line any of its issues needs. Issues whose source cannot be
+// read are kept.
+func (s *Scanner) Apply(ctx context.Context, issues []model.DebtIssue) ([]model.DebtIssue, []model.SuppressedIssue) {
+	var (
+		kept       []model.DebtIssue
+		suppressed []model.SuppressedIssue
+		failed     int
+	)
+
+	extent := make(map[string]int)
+	for _, issue := range issues {
+		_, to, _ := s.window(issue)
+		extent[issue.FilePath] = max(extent[issue.FilePath], to)
+	}
+
+	files := make(map[string]fileSource, len(extent))
+	for _, issue := range issues {
+		src, ok := files[issue.FilePath]
+		if !ok {
+			src.lines, src.err = s.reader.ReadLines(ctx, issue.FilePath, 1, extent[issue.FilePath])
+			files[issue.FilePath] = src
+		}
+		if src.err != nil {
+			util.Debug("Suppression: cannot read source for %s:%d: %v", issue.FilePath, issue.StartLine, src.err)
+			failed++
+			kept = append(kept, issue)
+			continue
+		}
+
+		annotations := s.annotationsFor(issue, src.lines)
+
+		if a, ok := findMatch(annotations, issue); ok {
+			suppressed = append(suppressed, model.SuppressedIssue{
+				Issue:  issue,
+				Rule:   strings.Join(a.rules, ","),
+				Reason: a.reason,
+				Line:   a.line,
+			})
+			continue
+		}
+		kept = append(kept, issue)
+	}
+
+	util.Debug("Suppression: %d issues suppressed, %d kept (%d unreadable) from %d files",
+		len(suppressed), len(kept), failed, len(files))
+	return kept, suppressed
+}
+
+// window returns the lines scanned for an issue's annotations: the top of the
+// file for file-level issues, otherwise the entity's first line and the
+// lookback lines above it
+func (s *Scanner) window(issue model.DebtIssue) (from, to int, header bool) {
+	if issue.EntityType == "file" || issue.EntityType == "class_pair" || issue.StartLine <= 1 {
+		return 1, s.lookback, true
+	}
+	return issue.StartLine - s.lookback, issue.StartLine, false
+}
+
+// annotationsFor returns annotations attached to the issue's entity: on its
+// first line or in the comment block immediately above it. File-level
+// issues use the comment block at the top of the file.
+func (s *Scanner) annotationsFor(issue model.DebtIssue, lines []source.Line) []annotation {
+	from, to, header := s.window(issue)
+	var window []source.Line
+	for _, line := range lines {
+		if line.Number >= from && line.Number <= to {
+			window = append(window, line)
+		}
+	}
+
+	dashComments := dashCommentExts[strings.ToLower(filepath.Ext(issue.FilePath))]
+	if header {
+		return headerAnnotations(window, dashComments)
+	}
+	return entityAnnotations(window, issue.StartLine, dashComments)
+}
+
+func entityAnnotations(lines []source.Line, startLine int, dashComments bool) []annotation {
+	var result []annotation
+
+	inBlock := false
+	for i := len(lines) - 1; i >= 0; i-- {
+		line := lines[i]
+		if line.Number > startLine {
+			continue
+		}
+		// The declaration line may carry a trailing comment; above it only
+		// a contiguous comment or decorator block belongs to the entity
+		if line.Number < startLine {
+			var comment bool
+			if comment, inBlock = commentAbove(line.Text, inBlock, dashComments); !comment {
+				break
+			}
+		}
+		if a, ok := parse(line); ok {
+			result = append(result, a)
+		}
+	}
+
+	return result
+}
+
+func headerAnnotations(lines []source.Line, dashComments bool) []annotation {
+	var result []annotation
+
+	inBlock := false
+	for _, line := range lines {
+		trimmed := strings.TrimSpace(line.Text)
+		switch {
+		case inBlock:
+			inBlock = !strings.Contains(trimmed, "*/")
+		case strings.HasPrefix(trimmed, "/*"):
+			inBlock = !strings.Contains(trimmed[2:], "*/")
+		case trimmed != "" && !isLineComment(trimmed, dashComments):
+			return result
+		}
+		if a, ok := parse(line); ok {
+			result = append(result, a)
+		}
+	}
+
+	return result
+}
+
+// parse extracts an annotation from a source line if it contains the marker
+func parse(line source.Line) (annotation, bool) {
+	idx := strings.Index(line.Text, Marker)
+	if idx < 0 {
+		return annotation{}, false
+	}
+
+	a := annotation{line: line.Number}
+	rest := line.Text[idx+len(Marker):]
+
+	// Markers such as "quality-bot:ignore-next" are not ours
+	if rest != "" && rest[0] != ' ' && rest[0] != '\t' {
+		return annotation{}, false
+	}
+
+	if m := reasonPattern.FindStringSubmatch(rest); m != nil {
+		a.reason = m[1] + m[2]
+		rest = strings.Replace(rest, m[0], "", 1)
+	}
+
+	// The first remaining token, if any, is a comma-separated rule list
+	fields := strings.Fields(strings.TrimSuffix(strings.TrimSpace(rest), "*/"))
+	if len(fields) > 0 {
+		for _, rule := range strings.Split(fields[0], ",") {
+			if rule = strings.TrimSpace(rule); rule != "" {
+				a.rules = append(a.rules, rule)
+			}
+		}
+	}
+
+	return a, true
+}
+
+func findMatch(annotations []annotation, issue model.DebtIssue) (annotation, bool) {
+	for _, a := range annotations {
+		if len(a.rules) == 0 {
+			return a, true
+		}
+		for _, rule := range a.rules {
+			if ruleMatches(rule, issue) {
+				return a, true
+			}
+		}
+	}
+	return annotation{}, false
+}
+
+// ruleMatches accepts "category/subcategory", "category", "category/*" and "*"
+func ruleMatches(rule string, issue model.DebtIssue) bool {
+	switch rule {
+	case "*", "all":
+		return true
+	case issue.RuleID(), string(issue.Category), string(issue.Category) + "/*":
+		return true
+	}
+	return false
+}
+
+// commentAbove classifies a line met while scanning upward from an entity.
+// inBlock reports whether the scan is inside a /* */ comment, which it enters
+// at the closing line; only there do lines such as " * text" count.
+func commentAbove(text string, inBlock, dashComments bool) (comment, block bool) {
+	trimmed := strings.TrimSpace(text)
+	if inBlock {
+		if strings.Contains(trimmed, "/*") {
+			// A block opened after code does not belong to the entity
+			return strings.HasPrefix(trimmed, "/*"), false
+		}
+		return true, true
+	}
+	if strings.HasSuffix(trimmed, "*/") && !strings.HasPrefix(trimmed, "/*") {
+		// The last line of a longer block, unless code precedes the comment
+		closing := !strings.Contains(trimmed, "/*")
+		return closing, closing
+	}
+	return isLineComment(trimmed, dashComments), false
+}
+
+// isLineComment reports whether a trimmed line is a comment or decorator on
+// its own. "--" only starts comments in some languages; elsewhere it is a
+// decrement.
+func isLineComment(trimmed string, dashComments bool) bool {
+	for _, prefix := range []string{"//", "#", "/*", "@"} {
+		if strings.HasPrefix(trimmed, prefix) {
+			return true
+		}
+	}
+	return dashComments && strings.HasPrefix(trimmed, "--")
+}
@@ -0,0 +1,128 @@
+package telemetry
+
+import (
+	"time"
+
+	"quality-bot/src/model"
+)
+
+// Default is the process-wide registry served on /metrics
+var Default = NewRegistry()
+
+// Tool health
+var (
+	codeAPIDuration = Default.NewHistogramVec("quality_bot_codeapi_request_duration_seconds",
+		"Latency of CodeAPI requests, per attempt.", DefaultBuckets, "endpoint")
+	codeAPIRequests = Default.NewCounterVec("quality_bot_codeapi_requests_total",
+		"CodeAPI request attempts by endpoint and HTTP status (\"error\" for transport failures).", "endpoint", "code")
+	codeAPIErrors = Default.NewCounterVec("quality_bot_codeapi_errors_total",
+		"CodeAPI requests that failed after all retries.", "endpoint")
+	codeAPIRetries = Default.NewCounterVec("quality_bot_codeapi_retries_total",
+		"CodeAPI request retries.", "endpoint")
+	detectorDuration = Default.NewHistogramVec("quality_bot_detector_duration_seconds",
+		"Run time of detectors.", DefaultBuckets, "detector")
+	detectorFailures = Default.NewCounterVec("quality_bot_detector_failures_total",
+		"Detector runs that returned an error.", "detector")
+	cacheLookups = Default.NewCounterVec("quality_bot_metrics_cache_lookups_total",
+		"Metrics provider lookups by metric kind and result (hit or miss).", "kind", "result")
+	analyses = Default.NewCounterVec("quality_bot_analyses_total",
+		"Analysis runs by repository and result (success or failure).", "repo", "result")
+)
+
+// Debt, per repository
+var (
+	debtIssues = Default.NewGaugeVec("quality_bot_debt_issues",
+		"Reported issues of the last full analysis.", "repo", "category", "severity")
+	debtScore = Default.NewGaugeVec("quality_bot_debt_score",
+		"Severity-weighted issues per 1,000 lines of the last full analysis.", "repo")
+	debtHotspots = Default.NewGaugeVec("quality_bot_debt_hotspots",
+		"Hotspot files of the last full analysis, by kind (issues or churn).", "repo", "kind")
+	remediationMinutes = Default.NewGaugeVec("quality_bot_remediation_minutes",
+		"Estimated remediation effort of the last full analysis.", "repo")
+	linesOfCode = Default.NewGaugeVec("quality_bot_lines_of_code",
+		"Lines of code covered by the last full analysis.", "repo")
+	lastAnalysis = Default.NewGaugeVec("quality_bot_last_analysis_timestamp_seconds",
+		"Completion time of the last full analysis.", "repo")
+)
+
+var (
+	categories = []model.Category{model.CategoryComplexity, model.CategorySize, model.CategoryCoupling,
+		model.CategoryDuplication, model.CategoryDeadCode}
+	severities = []model.Severity{model.SeverityLow, model.SeverityMedium, model.SeverityHigh, model.SeverityCritical}
+)
+
+// ObserveCodeAPIRequest records one CodeAPI request attempt. code is the
+// HTTP status, or "error" if no response was received.
+func ObserveCodeAPIRequest(endpoint, code string, d time.Duration) {
+	codeAPIDuration.Observe(d.Seconds(), endpoint)
+	codeAPIRequests.Inc(endpoint, code)
+}
+
+// CountCodeAPIRetry records a retried CodeAPI request
+func CountCodeAPIRetry(endpoint string) {
+	codeAPIRetries.Inc(endpoint)
+}
+
+// CountCodeAPIError records a CodeAPI request that failed for good
+func CountCodeAPIError(endpoint string) {
+	codeAPIErrors.Inc(endpoint)
+}
+
+// ObserveDetector records a detector run
+func ObserveDetector(name string, d time.Duration, err error) {
+	detectorDuration.Observe(d.Seconds(), name)
+	if err != nil {
+		detectorFailures.Inc(name)
+	}
+}
+
+// CountCacheLookup records a metrics provider lookup
+func CountCacheLookup(kind string, hit bool) {
+	result := "miss"
+	if hit {
+		result = "hit"
+	}
+	cacheLookups.Inc(kind, result)
+}
+
+// RecordAnalysisFailure counts a failed analysis run
+func RecordAnalysisFailure(repo string) {
+	analyses.Inc(repo, "failure")
+}
+
+// RecordReport counts a successful analysis run. Debt gauges are only
+// updated for full analyses; diff-scoped or owner-filtered runs cover part
+// of the repository and would make the series jump.
+func RecordReport(report *model.AnalysisReport, partial bool) {
+	repo := report.RepoName
+	analyses.Inc(repo, "success")
+	if partial || report.Scope != nil {
+		return
+	}
+
+	counts := make(map[model.Category]map[model.Severity]int)
+	for _, issue := range report.Issues {
+		if counts[issue.Category] == nil {
+			counts[issue.Category] = make(map[model.Severity]int)
+		}
+		counts[issue.Category][issue.Severity]++
+	}
+
+	// Every combination is set so dashboards see zeros rather than gaps
+	debtIssues.DeleteMatching("repo", repo)
+	for _, cat := range categories {
+		for _, sev := range severities {
+			debtIssues.Set(float64(counts[cat][sev]), repo, string(cat), string(sev))
+		}
+	}
+
+	s := report.Summary
+	debtScore.Set(s.DebtScore, repo)
+	debtHotspots.Set(float64(len(s.HotspotFiles)), repo, "issues")
+	debtHotspots.Set(float64(len(s.ChurnHotspots)), repo, "churn")
+	linesOfCode.Set(float64(s.LinesOfCode), repo)
+	if s.Remediation != nil {
+		remediationMinutes.Set(float64(s.Remediation.TotalMinutes), repo)
+	}
+	lastAnalysis.Set(float64(report.GeneratedAt.Unix()), repo)
+}
@@ -0,0 +1,308 @@
+package telemetry
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// labelSep joins label values into series keys; it cannot appear in UTF-8 text
+const labelSep = "\xff"
+
+// DefaultBuckets are histogram upper bounds in seconds, from fast queries to
+// slow detector runs
+var DefaultBuckets = []float64{0.01, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60, 300}
+
+// collector is a metric family that can write itself in the Prometheus text format
+type collector interface {
+	write(w *bufio.Writer)
+}
+
+// Registry holds metric families and renders them in the Prometheus text
+// exposition format
+type Registry struct {
+	mu         sync.Mutex
+	collectors []collector
+}
+
+// NewRegistry creates an empty registry
+func NewRegistry() *Registry {
+	return &Registry{}
+}
+
+func (r *Registry) register(c collector) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.collectors = append(r.collectors, c)
+}
+
+// Write renders every registered metric family
+func (r *Registry) Write(w io.Writer) error {
+	r.mu.Lock()
+	collectors := append([]collector(nil), r.collectors...)
+	r.mu.Unlock()
+
+	bw := bufio.NewWriter(w)
+	for _, c := range collectors {
+		c.write(bw)
+	}
+	return bw.Flush()
+}
+
+// Handler serves the registry for Prometheus scrapes
+func (r *Registry) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		if err := r.Write(w); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	})
+}
+
+// family holds the series of one metric, keyed by label values
+type family[T any] struct {
+	name   string
+	help   string
+	kind   string
+	labels []string
+
+	mu     sync.Mutex
+	series map[string]*T
+	values map[string][]string
+}
+
+func newFamily[T any](name, help, kind string, labels []string) *family[T] {
+	return &family[T]{
+		name:   name,
+		help:   help,
+		kind:   kind,
+		labels: labels,
+		series: make(map[string]*T),
+		values: make(map[string][]string),
+	}
+}
+
+// get returns the series for the label values, creating it with init; the
+// family lock must be held
+func (f *family[T]) get(labelValues []string, init func() *T) *T {
+	if len(labelValues) != len(f.labels) {
+		panic(fmt.Sprintf("metric %s: got %d label values, want %d", f.name, len(labelValues), len(f.labels)))
+	}
+	key := strings.Join(labelValues, labelSep)
+	s, ok := f.series[key]
+	if !ok {
+		s = init()
+		f.series[key] = s
+		f.values[key] = append([]string(nil), labelValues...)
+	}
+	return s
+}
+
+// deleteMatching drops every series whose label has the given value
+func (f *family[T]) deleteMatching(label, value string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	idx := -1
+	for i, l := range f.labels {
+		if l == label {
+			idx = i
+		}
+	}
+	if idx < 0 {
+		return
+	}
+	for key, values := range f.values {
+		if values[idx] == value {
+			delete(f.series, key)
+			delete(f.values, key)
+		}
+	}
+}
+
+// each visits the series in label order; the family lock must be held
+func (f *family[T]) each(visit func(labelValues []string, s *T)) {
+	keys := make([]string, 0, len(f.series))
+	for key := range f.series {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		visit(f.values[key], f.series[key])
+	}
+}
+
+func (f *family[T]) writeHeader(w *bufio.Writer) {
+	fmt.Fprintf(w, "# HELP %s %s\n", f.name, escapeHelp(f.help))
+	fmt.Fprintf(w, "# TYPE %s %s\n", f.name, f.kind)
+}
+
+// CounterVec is a family of monotonically increasing counters
+type CounterVec struct {
+	*family[float64]
+}
+
+// NewCounterVec registers a counter family
+func (r *Registry) NewCounterVec(name, help string, labels ...string) *CounterVec {
+	c := &CounterVec{newFamily[float64](name, help, "counter", labels)}
+	r.register(c)
+	return c
+}
+
+// Add increases the counter for the label values by v
+func (c *CounterVec) Add(v float64, labelValues ...string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	*c.get(labelValues, func() *float64 { return new(float64) }) += v
+}
+
+// Inc increases the counter for the label values by one
+func (c *CounterVec) Inc(labelValues ...string) {
+	c.Add(1, labelValues...)
+}
+
+func (c *CounterVec) write(w *bufio.Writer) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.writeHeader(w)
+	c.each(func(lv []string, v *float64) {
+		writeSample(w, c.name, c.labels, lv, "", "", *v)
+	})
+}
+
+// GaugeVec is a family of values that can go up and down
+type GaugeVec struct {
+	*family[float64]
+}
+
+// NewGaugeVec registers a gauge family
+func (r *Registry) NewGaugeVec(name, help string, labels ...string) *GaugeVec {
+	g := &GaugeVec{newFamily[float64](name, help, "gauge", labels)}
+	r.register(g)
+	return g
+}
+
+// Set sets the gauge for the label values
+func (g *GaugeVec) Set(v float64, labelValues ...string) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	*g.get(labelValues, func() *float64 { return new(float64) }) = v
+}
+
+// DeleteMatching removes every series whose label has the given value
+func (g *GaugeVec) DeleteMatching(label, value string) {
+	g.deleteMatching(label, value)
+}
+
+func (g *GaugeVec) write(w *bufio.Writer) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.writeHeader(w)
+	g.each(func(lv []string, v *float64) {
+		writeSample(w, g.name, g.labels, lv, "", "", *v)
+	})
+}
+
+// histogram is a single series of a HistogramVec
+type histogram struct {
+	counts []uint64 // Per bucket, not cumulative
+	sum    float64
+	count  uint64
+}
+
+// HistogramVec is a family of histograms sharing bucket bounds
+type HistogramVec struct {
+	*family[histogram]
+	buckets []float64
+}
+
+// NewHistogramVec registers a histogram family with the given upper bounds
+func (r *Registry) NewHistogramVec(name, help string, buckets []float64, labels ...string) *HistogramVec {
+	h := &HistogramVec{
+		family:  newFamily[histogram](name, help, "histogram", labels),
+		buckets: append([]float64(nil), buckets...),
+	}
+	sort.Float64s(h.buckets)
+	r.register(h)
+	return h
+}
+
+// Observe records a value for the label values
+func (h *HistogramVec) Observe(v float64, labelValues ...string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	s := h.get(labelValues, func() *histogram {
+		return &histogram{counts: make([]uint64, len(h.buckets))}
+	})
+	if i := sort.SearchFloat64s(h.buckets, v); i < len(h.buckets) {
+		s.counts[i]++
+	}
+	s.sum += v
+	s.count++
+}
+
+func (h *HistogramVec) write(w *bufio.Writer) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.writeHeader(w)
+	h.each(func(lv []string, s *histogram) {
+		var cumulative uint64
+		for i, bound := range h.buckets {
+			cumulative += s.counts[i]
+			writeSample(w, h.name+"_bucket", h.labels, lv, "le", formatFloat(bound), float64(cumulative))
+		}
+		writeSample(w, h.name+"_bucket", h.labels, lv, "le", "+Inf", float64(s.count))
+		writeSample(w, h.name+"_sum", h.labels, lv, "", "", s.sum)
+		writeSample(w, h.name+"_count", h.labels, lv, "", "", float64(s.count))
+	})
+}
+
+// writeSample writes one sample line; extraLabel is appended when set
+func writeSample(w *bufio.Writer, name string, labels, values []string, extraLabel, extraValue string, v float64) {
+	w.WriteString(name)
+
+	pairs := make([]string, 0, len(labels)+1)
+	for i, l := range labels {
+		pairs = append(pairs, l+`="`+escapeLabel(values[i])+`"`)
+	}
+	if extraLabel != "" {
+		pairs = append(pairs, extraLabel+`="`+extraValue+`"`)
+	}
+	if len(pairs) > 0 {
+		w.WriteString("{" + strings.Join(pairs, ",") + "}")
+	}
+
+	w.WriteString(" " + formatFloat(v) + "\n")
+}
+
+func formatFloat(v float64) string {
+	switch {
+	case math.IsInf(v, 1):
+		return "+Inf"
+	case math.IsInf(v, -1):
+		return "-Inf"
+	case math.IsNaN(v):
+		return "NaN"
+	}
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
+
+var (
+	labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+	helpEscaper  = strings.NewReplacer(`\`, `\\`, "\n", `\n`)
+)
+
+func escapeLabel(s string) string {
+	return labelEscaper.Replace(s)
+}
+
+func escapeHelp(s string) string {
+	return helpEscaper.Replace(s)
+}
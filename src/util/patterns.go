@@ -42,21 +42,25 @@ func NewExclusionMatcher(cfg config.ExclusionsConfig) *ExclusionMatcher {
 
 // Matches checks if an entity should be excluded
 func (m *ExclusionMatcher) Matches(filePath, className, funcName string) bool {
+	return len(m.MatchingRules(filePath, className, funcName)) > 0
+}
+
+// MatchingRules returns every exclusion rule matching an entity, written as
+// "<config key>: <pattern>"
+func (m *ExclusionMatcher) MatchingRules(filePath, className, funcName string) []string {
+	var rules []string
+
 	// Check exact file matches
 	for _, f := range m.files {
 		if filePath == f {
-			return true
+			rules = append(rules, "files: "+f)
 		}
 	}
 
-	// Check file patterns (glob)
+	// Check file patterns (glob), also trying ** patterns
 	for _, pattern := range m.filePatterns {
-		if matched, _ := filepath.Match(pattern, filePath); matched {
-			return true
-		}
-		// Also try matching with ** patterns
-		if matchDoubleGlob(pattern, filePath) {
-			return true
+		if matched, _ := filepath.Match(pattern, filePath); matched || matchDoubleGlob(pattern, filePath) {
+			rules = append(rules, "file_patterns: "+pattern)
 		}
 	}
 
@@ -64,7 +68,7 @@ func (m *ExclusionMatcher) Matches(filePath, className, funcName string) bool {
 	if className != "" {
 		for _, re := range m.classPatterns {
 			if re.MatchString(className) {
-				return true
+				rules = append(rules, "class_patterns: "+re.String())
 			}
 		}
 	}
@@ -73,12 +77,12 @@ func (m *ExclusionMatcher) Matches(filePath, className, funcName string) bool {
 	if funcName != "" {
 		for _, re := range m.functionPatterns {
 			if re.MatchString(funcName) {
-				return true
+				rules = append(rules, "function_patterns: "+re.String())
 			}
 		}
 	}
 
-	return false
+	return rules
 }
 
 // matchDoubleGlob handles ** patterns in globs
@@ -114,3 +118,47 @@ func MatchGlob(pattern, path string) bool {
 	matched, _ := filepath.Match(pattern, path)
 	return matched
 }
+
+// CompileGlob compiles a glob into a regexp matching whole slash-separated
+// paths. "*" and "?" do not cross "/", "**" matches any number of path
+// segments ("a/**/b" also matches "a/b"), and "[...]" classes are kept.
+func CompileGlob(pattern string) (*regexp.Regexp, error) {
+	var sb strings.Builder
+	sb.WriteString("^")
+
+	for i := 0; i < len(pattern); i++ {
+		c := pattern[i]
+		switch {
+		case c == '*' && strings.HasPrefix(pattern[i:], "**/"):
+			sb.WriteString("(?:.*/)?")
+			i += 2
+		case c == '*' && strings.HasPrefix(pattern[i:], "**"):
+			sb.WriteString(".*")
+			i++
+		case c == '*':
+			sb.WriteString("[^/]*")
+		case c == '?':
+			sb.WriteString("[^/]")
+		case c == '[':
+			end := strings.IndexByte(pattern[i+1:], ']')
+			if end < 0 {
+				sb.WriteString(`\[`)
+				continue
+			}
+			class := pattern[i+1 : i+1+end]
+			if strings.HasPrefix(class, "!") {
+				class = "^" + class[1:]
+			}
+			sb.WriteString("[" + class + "]")
+			i += end + 1
+		case c == '\\' && i+1 < len(pattern):
+			i++
+			sb.WriteString(regexp.QuoteMeta(string(pattern[i])))
+		default:
+			sb.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+
+	sb.WriteString("$")
+	return regexp.Compile(sb.String())
+}
@@ -0,0 +1,160 @@
+package util
+
+import (
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+
+	"quality-bot/src/config"
+)
+
+// ProfileMatcher resolves the detector settings in effect for a file. The
+// global settings are overridden by those of the file's language, which are
+// in turn overridden by the first path profile with a matching path.
+type ProfileMatcher struct {
+	languages map[string]languageProfile // Keyed by lowercase language name
+	profiles  []pathProfile
+
+	mu            sync.RWMutex
+	fileLanguages map[string]string // Language of each file as reported by CodeAPI
+}
+
+// languageProfile holds a language's settings merged over the global ones
+type languageProfile struct {
+	detectors config.DetectorsConfig
+	disabled  map[string]bool
+}
+
+// pathProfile is a profile with its globs compiled and its overrides applied
+// over the settings of every configured language ("" = global settings)
+type pathProfile struct {
+	name      string
+	paths     []*regexp.Regexp
+	detectors map[string]config.DetectorsConfig
+}
+
+// Settings are the detector settings in effect for a file
+type Settings struct {
+	Language  string // Empty if unknown
+	Profile   string // Empty if no path profile applies
+	Detectors config.DetectorsConfig
+
+	disabled map[string]bool
+}
+
+// RuleDisabled reports whether a rule ("category/subcategory") is disabled
+// for the file's language
+func (s Settings) RuleDisabled(ruleID string) bool {
+	return s.disabled[ruleID]
+}
+
+// NewProfileMatcher creates a new profile matcher from config. Languages and
+// profiles that fail to apply are skipped; the loader rejects them before
+// this point.
+func NewProfileMatcher(cfg *config.Config) *ProfileMatcher {
+	m := &ProfileMatcher{
+		languages: map[string]languageProfile{"": {detectors: cfg.Detectors}},
+	}
+
+	for name, lc := range cfg.Languages {
+		detectors, err := lc.Detectors.Apply(cfg.Detectors)
+		if err != nil {
+			Warn("Skipping settings of language %s: %v", name, err)
+			continue
+		}
+		lang := languageProfile{detectors: detectors, disabled: make(map[string]bool)}
+		for _, rule := range lc.DisabledRules {
+			lang.disabled[rule] = true
+		}
+		m.languages[strings.ToLower(name)] = lang
+	}
+
+	for _, p := range cfg.Profiles {
+		profile := pathProfile{name: p.Name, detectors: make(map[string]config.DetectorsConfig)}
+		for name, lang := range m.languages {
+			detectors, err := p.Detectors.Apply(lang.detectors)
+			if err != nil {
+				Warn("Skipping profile %s: %v", p.Name, err)
+				break
+			}
+			profile.detectors[name] = detectors
+		}
+		if len(profile.detectors) < len(m.languages) {
+			continue
+		}
+
+		for _, glob := range p.Paths {
+			re, err := CompileGlob(glob)
+			if err != nil {
+				Warn("Ignoring path %q of profile %s: %v", glob, p.Name, err)
+				continue
+			}
+			profile.paths = append(profile.paths, re)
+		}
+		m.profiles = append(m.profiles, profile)
+	}
+
+	return m
+}
+
+// SetFileLanguages records the language of each file as reported by
+// CodeAPI. Files without one fall back to their extension.
+func (m *ProfileMatcher) SetFileLanguages(languages map[string]string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.fileLanguages = languages
+}
+
+// Match returns the settings in effect for a file
+func (m *ProfileMatcher) Match(filePath string) Settings {
+	language := m.languageOf(filePath)
+	key := strings.ToLower(language)
+	lang, ok := m.languages[key]
+	if !ok {
+		key, lang = "", m.languages[""]
+	}
+
+	settings := Settings{Language: language, Detectors: lang.detectors, disabled: lang.disabled}
+	for _, p := range m.profiles {
+		for _, re := range p.paths {
+			if re.MatchString(filePath) {
+				settings.Profile = p.name
+				settings.Detectors = p.detectors[key]
+				return settings
+			}
+		}
+	}
+	return settings
+}
+
+func (m *ProfileMatcher) languageOf(filePath string) string {
+	m.mu.RLock()
+	language := m.fileLanguages[filePath]
+	m.mu.RUnlock()
+	if language != "" {
+		return language
+	}
+	return LanguageOf(filePath)
+}
+
+// LanguageOf returns the language of a file by its extension, using the
+// names CodeAPI reports, or "" if the extension is not recognized
+func LanguageOf(filePath string) string {
+	switch strings.TrimPrefix(filepath.Ext(filePath), ".") {
+	case "go":
+		return "go"
+	case "py":
+		return "python"
+	case "java":
+		return "java"
+	case "ts":
+		return "typescript"
+	case "js":
+		return "javascript"
+	case "cs":
+		return "csharp"
+	default:
+		return ""
+	}
+}